@@ -0,0 +1,124 @@
+package configr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AnnotatedEncoder is implemented by Encoders for formats that support
+// comments, allowing GenerateAnnotatedBlank() to write each key's details as
+// comments rather than in place of its value.
+type AnnotatedEncoder interface {
+	Encoder
+	MarshalAnnotated([]KeyAnnotation) ([]byte, error)
+}
+
+// KeyAnnotation describes a registered key for an AnnotatedEncoder. Value is
+// the value to write, the default when there is one or else a placeholder
+// (the zero value of the key's type when known).
+// Locations lists where Sources implementing KeyLocator would read the key
+// from, e.g. the environment variable name.
+type KeyAnnotation struct {
+	Key         string
+	Path        []string
+	Description string
+	Example     string
+	Type        string
+	Default     interface{}
+	Required    bool
+	Locations   []Origin
+	Value       interface{}
+}
+
+// Comments returns the annotation as lines of comment text (without comment
+// markers) for AnnotatedEncoders to write above the key
+func (a KeyAnnotation) Comments() []string {
+	lines := []string{}
+
+	description := a.Description
+	if a.Example != "" {
+		description = strings.TrimSpace(description + " e.g. " + a.Example)
+	}
+	if description != "" {
+		lines = append(lines, description)
+	}
+
+	details := []string{}
+	if a.Type != "" {
+		details = append(details, "type: "+a.Type)
+	}
+	if a.Default != nil {
+		details = append(details, fmt.Sprintf("default: %v", a.Default))
+	}
+	if a.Required {
+		details = append(details, "required")
+	}
+	if len(details) > 0 {
+		lines = append(lines, strings.Join(details, ", "))
+	}
+
+	for _, location := range a.Locations {
+		lines = append(lines, fmt.Sprintf("%s: %s", location.Source, location.Location))
+	}
+
+	return lines
+}
+
+// GenerateAnnotatedBlank generates a 'blank' configuration like
+// GenerateBlank() but passes every registered key, in registration order, to
+// an AnnotatedEncoder so descriptions, types, defaults, required flags and
+// source locations can be written as native comments.
+func GenerateAnnotatedBlank(e AnnotatedEncoder) ([]byte, error) {
+	return globalConfigr.GenerateAnnotatedBlank(e)
+}
+func (c *Configr) GenerateAnnotatedBlank(e AnnotatedEncoder) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.registeredKeys) == 0 {
+		return []byte{}, ErrNoRegisteredValues
+	}
+
+	annotations := make([]KeyAnnotation, 0, len(c.keyOrder))
+	for _, key := range c.keyOrder {
+		annotations = append(annotations, c.annotateKey(key))
+	}
+
+	return e.MarshalAnnotated(annotations)
+}
+
+func (c *Configr) annotateKey(key string) KeyAnnotation {
+	_, isRequired := c.requiredKeys[key]
+	annotation := KeyAnnotation{
+		Key:         key,
+		Path:        c.keySplitterFn(key),
+		Description: c.registeredKeys[key],
+		Example:     c.examples[key],
+		Required:    isRequired,
+		Value:       "",
+	}
+
+	// Secret defaults are left out, as they are from JSONSchema()
+	if defaultValue, found := c.defaultValues[key]; found && !c.load().isSecret(key) {
+		annotation.Default = defaultValue
+		annotation.Value = defaultValue
+	}
+	if keyType := c.keyType(key); keyType != nil {
+		annotation.Type = keyType.String()
+		if annotation.Default == nil {
+			annotation.Value = reflect.Zero(keyType).Interface()
+		}
+	}
+
+	for i, source := range c.sources {
+		if locator, ok := innerSource(source).(KeyLocator); ok {
+			annotation.Locations = append(annotation.Locations, Origin{
+				Source:   sourceName(source, i),
+				Location: locator.Locate(key, c.keySplitterFn),
+			})
+		}
+	}
+
+	return annotation
+}
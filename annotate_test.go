@@ -0,0 +1,109 @@
+package configr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAnnotatedGenerator struct {
+	MockGenerator
+}
+
+func (m *MockAnnotatedGenerator) MarshalAnnotated(annotations []KeyAnnotation) ([]byte, error) {
+	args := m.Called(annotations)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func Test_GenerateAnnotatedBlank_ItPassesKeysInRegistrationOrder(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "env:APP"})
+	g := &MockAnnotatedGenerator{}
+
+	config.RegisterKey("server.port", "Port to listen on", 8080)
+	config.RequireKey("email.subject", "Subject line")
+	config.RegisterKey("server.host", "", "localhost")
+
+	g.On("MarshalAnnotated", []KeyAnnotation{
+		{
+			Key:         "server.port",
+			Path:        []string{"server", "port"},
+			Description: "Port to listen on",
+			Type:        "int",
+			Default:     8080,
+			Locations:   []Origin{{Source: "env:APP", Location: "env:APP:server.port"}},
+			Value:       8080,
+		},
+		{
+			Key:         "email.subject",
+			Path:        []string{"email", "subject"},
+			Description: "Subject line",
+			Required:    true,
+			Locations:   []Origin{{Source: "env:APP", Location: "env:APP:email.subject"}},
+			Value:       "",
+		},
+		{
+			Key:       "server.host",
+			Path:      []string{"server", "host"},
+			Type:      "string",
+			Default:   "localhost",
+			Locations: []Origin{{Source: "env:APP", Location: "env:APP:server.host"}},
+			Value:     "localhost",
+		},
+	}).Return([]byte{}, nil)
+
+	_, err := config.GenerateAnnotatedBlank(g)
+
+	assert.NoError(t, err)
+	g.AssertExpectations(t)
+}
+
+func Test_GenerateAnnotatedBlank_ItLeavesOutSecretDefaults(t *testing.T) {
+	config := New()
+	g := &MockAnnotatedGenerator{}
+
+	config.RegisterSecretKey("db.password", "Database password", "hunter2")
+
+	g.On("MarshalAnnotated", []KeyAnnotation{
+		{
+			Key:         "db.password",
+			Path:        []string{"db", "password"},
+			Description: "Database password",
+			Type:        "string",
+			Value:       "",
+		},
+	}).Return([]byte{}, nil)
+
+	_, err := config.GenerateAnnotatedBlank(g)
+
+	assert.NoError(t, err)
+	g.AssertExpectations(t)
+}
+
+func Test_GenerateAnnotatedBlank_ItReturnsErrorIfNoRegisteredValuesToGenerate(t *testing.T) {
+	g := &MockAnnotatedGenerator{}
+
+	_, err := New().GenerateAnnotatedBlank(g)
+
+	assert.Equal(t, ErrNoRegisteredValues, err)
+	g.AssertNotCalled(t, "MarshalAnnotated", mock.Anything)
+}
+
+func Test_KeyAnnotation_Comments(t *testing.T) {
+	annotation := KeyAnnotation{
+		Description: "Port to listen on",
+		Example:     "8080",
+		Type:        "int",
+		Default:     80,
+		Required:    true,
+		Locations:   []Origin{{Source: "env", Location: "SERVER_PORT"}},
+	}
+
+	assert.Equal(t, []string{
+		"Port to listen on e.g. 8080",
+		"type: int, default: 80, required",
+		"env: SERVER_PORT",
+	}, annotation.Comments())
+	assert.Equal(t, []string{}, KeyAnnotation{}.Comments())
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+func runConvert(args []string, stdout io.Writer) (int, error) {
+	flags := newFlagSet("convert")
+	from := flags.String("from", "", "format of the input file")
+	to := flags.String("to", "", "format to convert to")
+	if err := flags.Parse(args); err != nil || flags.NArg() < 1 || flags.NArg() > 2 {
+		return exitUsage, errUsage
+	}
+	input, output := flags.Arg(0), flags.Arg(1)
+
+	if *to == "" && output == "" {
+		return exitUsage, errUsage
+	}
+
+	values, err := readFile(input, *from)
+	if err != nil {
+		return exitFailed, err
+	}
+
+	encoder, err := encoderFor(*to, output)
+	if err != nil {
+		return exitFailed, err
+	}
+
+	encoded, err := encoder.Marshal(values)
+	if err != nil {
+		return exitFailed, err
+	}
+
+	if output == "" {
+		_, err = stdout.Write(encoded)
+	} else {
+		err = ioutil.WriteFile(output, encoded, 0644)
+	}
+	if err != nil {
+		return exitFailed, err
+	}
+
+	return exitOK, nil
+}
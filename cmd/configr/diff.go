@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/adrianduke/configr"
+)
+
+// runDiff lists the keys added, removed or changed going from the first file
+// to the second, exiting non zero when there are any
+func runDiff(args []string, stdout io.Writer) (int, error) {
+	flags := newFlagSet("diff")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 2 {
+		return exitUsage, errUsage
+	}
+
+	oldConfig, err := parseFile(flags.Arg(0))
+	if err != nil {
+		return exitFailed, err
+	}
+	newConfig, err := parseFile(flags.Arg(1))
+	if err != nil {
+		return exitFailed, err
+	}
+
+	changes := configr.Diff(oldConfig, newConfig)
+	if len(changes) == 0 {
+		return exitOK, nil
+	}
+
+	fmt.Fprintln(stdout, changes)
+	return exitFailed, nil
+}
+
+func parseFile(path string) (*configr.Configr, error) {
+	file, err := openFile(path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	config := configr.New()
+	config.AddSource(normalisedFile{file})
+
+	return config, config.Parse()
+}
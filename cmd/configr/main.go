@@ -0,0 +1,196 @@
+// Command configr helps ops teams work with configuration files without
+// writing Go: converting between formats, merging files and env vars,
+// diffing and validating.
+//
+//	configr convert [-from json] [-to toml] <input> [output]
+//	configr merge [-env-prefix APP] [-format json] <file>...
+//	configr diff <file> <file>
+//	configr validate (-schema schema.json | -registry blank.json) <file>
+//
+// Formats are named after the registered file codecs (json, toml) and are
+// picked from file extensions when not given.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adrianduke/configr"
+	_ "github.com/adrianduke/configr/sources/file/json"
+	_ "github.com/adrianduke/configr/sources/file/toml"
+)
+
+const (
+	exitOK = iota
+	exitFailed
+	exitUsage
+)
+
+var errUsage = errors.New("invalid usage")
+
+// newFlagSet returns a FlagSet that leaves reporting usage errors to run()
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+
+	return flags
+}
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string, stdout io.Writer) (int, error)
+}
+
+var commands = []command{
+	{"convert", "[-from format] [-to format] <input> [output]", "convert a config file between formats", runConvert},
+	{"merge", "[-env-prefix prefix] [-format format] <file>...", "print the effective merge of files (later files win) and env vars", runMerge},
+	{"diff", "<file> <file>", "list keys added, removed or changed between two config files", runDiff},
+	{"validate", "(-schema file | -registry file) <file>", "validate a config file against a JSON Schema or blank config", runValidate},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		code, err := cmd.run(args[1:], stdout)
+		if err == errUsage {
+			fmt.Fprintf(stderr, "usage: configr %s %s\n", cmd.name, cmd.usage)
+			return exitUsage
+		}
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+		}
+		return code
+	}
+
+	printUsage(stderr)
+	return exitUsage
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: configr <command> [arguments]")
+	fmt.Fprintln(w, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-9s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "\nformats: %s\n", strings.Join(formats(), ", "))
+}
+
+func formats() []string {
+	names := []string{}
+	for name := range configr.RegisteredFileDecoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// openFile returns a File source for path, using format when given or else
+// the file's extension
+func openFile(path, format string) (*configr.File, error) {
+	file := configr.NewFile(path)
+	if format != "" {
+		file.SetEncodingName(format)
+	}
+
+	if _, err := file.Unmarshal(nil, nil); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return file, nil
+}
+
+// readFile decodes a config file into its values, whole numbers decoded as
+// floats (as JSON does) are converted to int64 so they stay whole numbers when
+// encoded in other formats
+func readFile(path, format string) (map[string]interface{}, error) {
+	file, err := openFile(path, format)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := file.Unmarshal(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return normaliseNumbers(values).(map[string]interface{}), nil
+}
+
+func normaliseNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, subValue := range v {
+			v[key] = normaliseNumbers(subValue)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normaliseNumbers(item)
+		}
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+	}
+
+	return value
+}
+
+// encoderFor returns the named encoder, or the one registered for path's
+// extension
+func encoderFor(format, path string) (configr.Encoder, error) {
+	if format == "" {
+		format = configr.ExtensionToEncoderName[strings.TrimPrefix(filepath.Ext(path), ".")]
+	}
+
+	if encoder, found := configr.RegisteredFileEncoders[format]; found {
+		return encoder, nil
+	}
+
+	return nil, fmt.Errorf("unknown format %q, expected one of: %s", format, strings.Join(formats(), ", "))
+}
+
+// flatten converts nested values into a single level map keyed by dotted
+// paths
+func flatten(values map[string]interface{}) map[string]interface{} {
+	flattened := make(map[string]interface{})
+	flattenInto(flattened, "", values)
+
+	return flattened
+}
+
+func flattenInto(flattened map[string]interface{}, prefix string, values map[string]interface{}) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if subMap, ok := value.(map[string]interface{}); ok && len(subMap) > 0 {
+			flattenInto(flattened, key, subMap)
+			continue
+		}
+		flattened[key] = value
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "configr")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func runCommand(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func Test_Convert_ItConvertsBetweenFormats(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"a.json": `{"server": {"port": 8080, "host": "localhost"}}`})
+	defer os.RemoveAll(dir)
+
+	code, stdout, _ := runCommand("convert", "-to", "toml", filepath.Join(dir, "a.json"))
+
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "[server]\n  host = \"localhost\"\n  port = 8080\n", stdout)
+
+	code, _, _ = runCommand("convert", filepath.Join(dir, "a.json"), filepath.Join(dir, "b.toml"))
+	assert.Equal(t, exitOK, code)
+	converted, _ := ioutil.ReadFile(filepath.Join(dir, "b.toml"))
+	assert.Equal(t, stdout, string(converted))
+}
+
+func Test_Merge_ItPrioritisesLaterFilesAndEnvVars(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.json": `{"server": {"port": 8080, "host": "localhost"}, "debug": true}`,
+		"b.json": `{"server": {"port": 9090}}`,
+	})
+	defer os.RemoveAll(dir)
+	os.Setenv("CONFIGRTEST_SERVER_HOST", "example.com")
+	defer os.Unsetenv("CONFIGRTEST_SERVER_HOST")
+
+	code, stdout, _ := runCommand("merge", "-env-prefix", "configrtest", "-format", "toml", filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json"))
+
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "debug = true\n\n[server]\n  host = \"example.com\"\n  port = 9090\n", stdout)
+}
+
+func Test_Diff_ItListsChangedKeys(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.json": `{"server": {"port": 8080, "host": "localhost"}, "same": 1}`,
+		"b.toml": "same = 1\nadded = \"x\"\n[server]\nport = 9090\n",
+	})
+	defer os.RemoveAll(dir)
+
+	code, stdout, _ := runCommand("diff", filepath.Join(dir, "a.json"), filepath.Join(dir, "b.toml"))
+
+	assert.Equal(t, exitFailed, code)
+	assert.Equal(t, "+ added = \"x\"\n- server.host = \"localhost\"\n~ server.port: 8080 -> 9090\n", stdout)
+
+	code, stdout, _ = runCommand("diff", filepath.Join(dir, "a.json"), filepath.Join(dir, "a.json"))
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "", stdout)
+}
+
+func Test_Validate_ItChecksAgainstASchema(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"schema.json": `{
+			"type": "object",
+			"required": ["server"],
+			"additionalProperties": false,
+			"properties": {
+				"server": {
+					"type": "object",
+					"required": ["host"],
+					"properties": {
+						"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+						"mode": {"enum": ["debug", "info"]},
+						"tags": {"type": "array", "items": {"type": "string"}}
+					}
+				}
+			}
+		}`,
+		"good.json": `{"server": {"host": "localhost", "port": 8080}}`,
+		"bad.json":  `{"server": {"port": 70000, "mode": "loud", "tags": ["a", 1]}, "extra": true}`,
+	})
+	defer os.RemoveAll(dir)
+
+	code, stdout, _ := runCommand("validate", "-schema", filepath.Join(dir, "schema.json"), filepath.Join(dir, "good.json"))
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, stdout, "good.json is valid")
+
+	code, stdout, _ = runCommand("validate", "-schema", filepath.Join(dir, "schema.json"), filepath.Join(dir, "bad.json"))
+	assert.Equal(t, exitFailed, code)
+	assert.Contains(t, stdout, "  - extra: is not allowed\n"+
+		"  - server.host: is required\n"+
+		"  - server.mode: must be one of [debug info]\n"+
+		"  - server.port: must be at most 65535\n"+
+		"  - server.tags[1]: must be of type string, got integer\n")
+}
+
+func Test_Validate_ItChecksAgainstABlankConfig(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"blank.json":  `{"server": {"host": "*** Host to listen on ***", "port": 80}}`,
+		"config.json": `{"server": {"prot": 8080}}`,
+	})
+	defer os.RemoveAll(dir)
+
+	code, stdout, _ := runCommand("validate", "-registry", filepath.Join(dir, "blank.json"), filepath.Join(dir, "config.json"))
+
+	assert.Equal(t, exitFailed, code)
+	assert.Contains(t, stdout, "did you mean 'server.port'?")
+	assert.Contains(t, stdout, "[server.host]")
+}
+
+func Test_Run_ItPrintsUsage(t *testing.T) {
+	code, _, stderr := runCommand()
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, "usage: configr <command>")
+
+	code, _, stderr = runCommand("diff", "only-one")
+	assert.Equal(t, exitUsage, code)
+	assert.Equal(t, "usage: configr diff <file> <file>\n", stderr)
+
+	code, _, stderr = runCommand("validate", "-schema", "a", "-registry", "b", "c")
+	assert.Equal(t, exitUsage, code)
+}
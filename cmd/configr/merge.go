@@ -0,0 +1,73 @@
+package main
+
+import (
+	"io"
+
+	"github.com/adrianduke/configr"
+	"github.com/adrianduke/configr/sources"
+)
+
+// runMerge prints the effective configuration of several files, later files
+// take priority over earlier ones and env vars (when a prefix is given) over
+// all of them
+func runMerge(args []string, stdout io.Writer) (int, error) {
+	flags := newFlagSet("merge")
+	envPrefix := flags.String("env-prefix", "", "also read env vars with this prefix, e.g. APP for APP_SERVER_PORT")
+	envVars := flags.Bool("env", false, "also read env vars without a prefix")
+	format := flags.String("format", "", "format to print (defaults to the first file's)")
+	if err := flags.Parse(args); err != nil || flags.NArg() == 0 {
+		return exitUsage, errUsage
+	}
+	paths := flags.Args()
+
+	encoder, err := encoderFor(*format, paths[0])
+	if err != nil {
+		return exitFailed, err
+	}
+
+	config := configr.New()
+	if *envPrefix != "" || *envVars {
+		config.AddSource(sources.NewEnvVars(*envPrefix))
+	}
+
+	for i := len(paths) - 1; i >= 0; i-- {
+		file, err := openFile(paths[i], "")
+		if err != nil {
+			return exitFailed, err
+		}
+		config.AddSource(normalisedFile{file})
+
+		// Register every key found so env vars can override them
+		values, _ := file.Unmarshal(nil, nil)
+		for key := range flatten(values) {
+			config.RegisterKey(key, "", nil)
+		}
+	}
+
+	if err := config.Parse(); err != nil {
+		return exitFailed, err
+	}
+
+	merged, err := config.Dump(encoder, configr.DumpOptions{ShowSecrets: true})
+	if err != nil {
+		return exitFailed, err
+	}
+
+	_, err = stdout.Write(merged)
+	return exitOK, err
+}
+
+// normalisedFile converts whole numbers decoded as floats to int64, see
+// readFile()
+type normalisedFile struct {
+	*configr.File
+}
+
+func (f normalisedFile) Unmarshal(keys []string, keySplitter configr.KeySplitter) (map[string]interface{}, error) {
+	values, err := f.File.Unmarshal(keys, keySplitter)
+	if err != nil {
+		return values, err
+	}
+
+	return normaliseNumbers(values).(map[string]interface{}), nil
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// validateSchema checks value against a JSON Schema, returning a problem for
+// every failing keyword. It supports the keywords configr.JSONSchema()
+// generates along with the common validation keywords, references ($ref) and
+// schema composition (allOf, anyOf...) are not supported.
+func validateSchema(schema interface{}, value interface{}, path string) []string {
+	rules, ok := schema.(map[string]interface{})
+	if !ok {
+		if allowed, isBool := schema.(bool); isBool && !allowed {
+			return []string{problem(path, "is not allowed")}
+		}
+		return nil
+	}
+
+	if types, found := rules["type"]; found && !matchesAnyType(types, value) {
+		return []string{problem(path, fmt.Sprintf("must be of type %v, got %s", types, describeType(value)))}
+	}
+
+	var problems []string
+	add := func(message string) {
+		problems = append(problems, problem(path, message))
+	}
+
+	if enum, ok := rules["enum"].([]interface{}); ok && !containsValue(enum, value) {
+		add(fmt.Sprintf("must be one of %v", enum))
+	}
+
+	if number, ok := toNumber(value); ok {
+		if minimum, ok := toNumber(rules["minimum"]); ok && number < minimum {
+			add(fmt.Sprintf("must be at least %v", minimum))
+		}
+		if maximum, ok := toNumber(rules["maximum"]); ok && number > maximum {
+			add(fmt.Sprintf("must be at most %v", maximum))
+		}
+		if minimum, ok := toNumber(rules["exclusiveMinimum"]); ok && number <= minimum {
+			add(fmt.Sprintf("must be greater than %v", minimum))
+		}
+		if maximum, ok := toNumber(rules["exclusiveMaximum"]); ok && number >= maximum {
+			add(fmt.Sprintf("must be less than %v", maximum))
+		}
+	}
+
+	if s, ok := value.(string); ok {
+		length := float64(utf8.RuneCountInString(s))
+		if minLength, ok := toNumber(rules["minLength"]); ok && length < minLength {
+			add(fmt.Sprintf("must be at least %v characters", minLength))
+		}
+		if maxLength, ok := toNumber(rules["maxLength"]); ok && length > maxLength {
+			add(fmt.Sprintf("must be at most %v characters", maxLength))
+		}
+		if pattern, ok := rules["pattern"].(string); ok {
+			if matched, err := regexp.MatchString(pattern, s); err != nil || !matched {
+				add(fmt.Sprintf("must match %s", pattern))
+			}
+		}
+	}
+
+	if items, ok := toSlice(value); ok {
+		if minItems, ok := toNumber(rules["minItems"]); ok && float64(len(items)) < minItems {
+			add(fmt.Sprintf("must have at least %v items", minItems))
+		}
+		if maxItems, ok := toNumber(rules["maxItems"]); ok && float64(len(items)) > maxItems {
+			add(fmt.Sprintf("must have at most %v items", maxItems))
+		}
+		if itemSchema, found := rules["items"]; found {
+			for i, item := range items {
+				problems = append(problems, validateSchema(itemSchema, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+
+	if object, ok := value.(map[string]interface{}); ok {
+		problems = append(problems, validateObject(rules, object, path)...)
+	}
+
+	return problems
+}
+
+func validateObject(rules map[string]interface{}, object map[string]interface{}, path string) []string {
+	var problems []string
+
+	if required, ok := rules["required"].([]interface{}); ok {
+		for _, name := range required {
+			if key, ok := name.(string); ok {
+				if _, found := object[key]; !found {
+					problems = append(problems, problem(joinPath(path, key), "is required"))
+				}
+			}
+		}
+	}
+
+	properties, _ := rules["properties"].(map[string]interface{})
+	additional, hasAdditional := rules["additionalProperties"]
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if propertySchema, found := properties[key]; found {
+			problems = append(problems, validateSchema(propertySchema, object[key], joinPath(path, key))...)
+		} else if hasAdditional {
+			problems = append(problems, validateSchema(additional, object[key], joinPath(path, key))...)
+		}
+	}
+
+	return problems
+}
+
+func matchesAnyType(types interface{}, value interface{}) bool {
+	switch t := types.(type) {
+	case string:
+		return matchesType(t, value)
+	case []interface{}:
+		for _, name := range t {
+			if s, ok := name.(string); ok && matchesType(s, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+func matchesType(name string, value interface{}) bool {
+	switch name {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		switch value.(type) {
+		case string, time.Time:
+			return true
+		}
+		return false
+	case "number":
+		_, ok := toNumber(value)
+		return ok
+	case "integer":
+		number, ok := toNumber(value)
+		return ok && number == math.Trunc(number)
+	case "array":
+		_, ok := toSlice(value)
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+
+	return false
+}
+
+func describeType(value interface{}) string {
+	for _, name := range []string{"null", "boolean", "integer", "number", "string", "array", "object"} {
+		if matchesType(name, value) {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+
+	return 0, false
+}
+
+func toSlice(value interface{}) ([]interface{}, bool) {
+	if value == nil || reflect.TypeOf(value).Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	reflected := reflect.ValueOf(value)
+	items := make([]interface{}, reflected.Len())
+	for i := range items {
+		items[i] = reflected.Index(i).Interface()
+	}
+
+	return items, true
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	number, isNumber := toNumber(value)
+	for _, candidate := range values {
+		if candidateNumber, ok := toNumber(candidate); ok && isNumber && candidateNumber == number {
+			return true
+		}
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func problem(path, message string) string {
+	if path == "" {
+		path = "(root)"
+	}
+
+	return path + ": " + message
+}
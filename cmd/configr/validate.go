@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/adrianduke/configr"
+)
+
+const blankDescriptionWrapper = "***"
+
+// runValidate checks a config file against a JSON Schema (e.g. one generated
+// by configr.JSONSchema()) or against a blank config generated by
+// configr.GenerateBlank(), in which case unknown keys are reported in strict
+// mode and keys left holding a description are required
+func runValidate(args []string, stdout io.Writer) (int, error) {
+	flags := newFlagSet("validate")
+	schemaPath := flags.String("schema", "", "JSON Schema to validate against")
+	registryPath := flags.String("registry", "", "blank config listing the registered keys and defaults")
+	format := flags.String("format", "", "format of the file (defaults to its extension)")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 || (*schemaPath == "") == (*registryPath == "") {
+		return exitUsage, errUsage
+	}
+	path := flags.Arg(0)
+
+	var problems []string
+	var err error
+	if *schemaPath != "" {
+		problems, err = validateAgainstSchema(*schemaPath, path, *format)
+	} else {
+		problems, err = validateAgainstRegistry(*registryPath, path, *format)
+	}
+	if err != nil {
+		return exitFailed, err
+	}
+
+	if len(problems) > 0 {
+		fmt.Fprintf(stdout, "%s is invalid:\n", path)
+		for _, problem := range problems {
+			fmt.Fprintf(stdout, "  - %s\n", problem)
+		}
+		return exitFailed, nil
+	}
+
+	fmt.Fprintf(stdout, "%s is valid\n", path)
+	return exitOK, nil
+}
+
+func validateAgainstSchema(schemaPath, path, format string) ([]string, error) {
+	schemaBytes, err := ioutil.ReadFile(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema interface{}
+	if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+		return nil, fmt.Errorf("%s: %v", schemaPath, err)
+	}
+
+	values, err := readFile(path, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateSchema(schema, values, ""), nil
+}
+
+func validateAgainstRegistry(registryPath, path, format string) ([]string, error) {
+	registry, err := readFile(registryPath, "")
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := openFile(path, format)
+	if err != nil {
+		return nil, err
+	}
+
+	config := configr.New()
+	config.SetStrict(true)
+	config.AddSource(file)
+
+	for key, value := range flatten(registry) {
+		if description, ok := blankDescription(value); ok {
+			config.RequireKey(key, description)
+			continue
+		}
+		config.RegisterKey(key, "", value)
+	}
+
+	err = config.Parse()
+
+	var parseErrs configr.ParseErrors
+	if errors.As(err, &parseErrs) {
+		problems := make([]string, len(parseErrs))
+		for i, parseErr := range parseErrs {
+			problems[i] = parseErr.Error()
+		}
+		return problems, nil
+	}
+
+	return nil, err
+}
+
+// blankDescription reports whether a blank config value is a description
+// placeholder, e.g. "*** Email from address ***"
+func blankDescription(value interface{}) (string, bool) {
+	s, ok := value.(string)
+	if !ok || !strings.HasPrefix(s, blankDescriptionWrapper) || !strings.HasSuffix(s, blankDescriptionWrapper) || len(s) < 2*len(blankDescriptionWrapper) {
+		return "", false
+	}
+
+	return strings.TrimSpace(s[len(blankDescriptionWrapper) : len(s)-len(blankDescriptionWrapper)]), true
+}
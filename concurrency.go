@@ -0,0 +1,64 @@
+package configr
+
+import (
+	"context"
+	"sync"
+)
+
+// SetSourceConcurrency sets how many sources Parse() unmarshals at once, by
+// default sources are unmarshalled one after another. n < 1 unmarshals every
+// source at once. However they are fetched, values are still merged in FILO
+// order.
+func SetSourceConcurrency(n int) {
+	globalConfigr.SetSourceConcurrency(n)
+}
+func (c *Configr) SetSourceConcurrency(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sourceConcurrency = n
+}
+
+type sourceResult struct {
+	values map[string]interface{}
+	err    error
+}
+
+// fetchSources unmarshals every source, returning results in the same order
+// as c.sources. Sources are started in priority order (lowest first).
+func (c *Configr) fetchSources(ctx context.Context, expectedKeys []string) []sourceResult {
+	results := make([]sourceResult, len(c.sources))
+
+	if c.sourceConcurrency == 1 {
+		for i := len(c.sources) - 1; i >= 0; i-- {
+			values, err := unmarshalSource(ctx, c.sources[i], c.sourceCalls[i], expectedKeys, c.keySplitterFn)
+			results[i] = sourceResult{values, err}
+		}
+		return results
+	}
+
+	limit := c.sourceConcurrency
+	if limit < 1 {
+		limit = len(c.sources)
+	}
+	slots := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for i := len(c.sources) - 1; i >= 0; i-- {
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func(i int, source Source, calls *sourceCalls) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			values, err := unmarshalSource(ctx, source, calls, expectedKeys, c.keySplitterFn)
+			results[i] = sourceResult{values, err}
+		}(i, c.sources[i], c.sourceCalls[i])
+	}
+	wg.Wait()
+
+	return results
+}
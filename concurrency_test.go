@@ -0,0 +1,118 @@
+package configr
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func delayedSource(delay time.Duration, values map[string]interface{}, inFlight, maxInFlight *int32) Source {
+	return SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		current := atomic.AddInt32(inFlight, 1)
+		defer atomic.AddInt32(inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(maxInFlight, max, current) {
+				break
+			}
+		}
+
+		time.Sleep(delay)
+		return values, nil
+	})
+}
+
+// barrier is waited on by sources until n of them have started unmarshalling
+type barrier struct {
+	n       int32
+	started int32
+	ready   chan struct{}
+}
+
+func newBarrier(n int32) *barrier {
+	return &barrier{n: n, ready: make(chan struct{})}
+}
+
+func (b *barrier) source(values map[string]interface{}) Source {
+	return SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		if atomic.AddInt32(&b.started, 1) == b.n {
+			close(b.ready)
+		}
+
+		select {
+		case <-b.ready:
+			return values, nil
+		case <-time.After(5 * time.Second):
+			return nil, errors.New("timed out waiting for other sources to start")
+		}
+	})
+}
+
+func Test_SetSourceConcurrency_ItFetchesSourcesConcurrently(t *testing.T) {
+	b := newBarrier(4)
+
+	config := New()
+	config.SetSourceConcurrency(0)
+	for i := 0; i < 4; i++ {
+		config.AddSource(b.source(map[string]interface{}{"t1": i}))
+	}
+
+	assert.NoError(t, config.Parse())
+}
+
+func Test_SetSourceConcurrency_ItLimitsSourcesFetchedAtOnce(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	config := New()
+	config.SetSourceConcurrency(2)
+	for i := 0; i < 5; i++ {
+		config.AddSource(delayedSource(10*time.Millisecond, map[string]interface{}{"t1": i}, &inFlight, &maxInFlight))
+	}
+
+	assert.NoError(t, config.Parse())
+
+	assert.True(t, atomic.LoadInt32(&maxInFlight) <= 2)
+}
+
+func Test_SetSourceConcurrency_ItMergesInPriorityOrderRegardlessOfFinishOrder(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	config := New()
+	config.SetSourceConcurrency(0)
+	config.AddSource(delayedSource(40*time.Millisecond, map[string]interface{}{"t1": "first"}, &inFlight, &maxInFlight))
+	config.AddSource(delayedSource(20*time.Millisecond, map[string]interface{}{"t1": "second", "t2": "second"}, &inFlight, &maxInFlight))
+	config.AddSource(delayedSource(0, map[string]interface{}{"t1": "third", "t2": "third", "t3": "third"}, &inFlight, &maxInFlight))
+
+	assert.NoError(t, config.Parse())
+
+	for key, expected := range map[string]string{"t1": "first", "t2": "second", "t3": "third"} {
+		value, _ := config.String(key)
+		assert.Equal(t, expected, value)
+	}
+}
+
+func Test_SetSourceConcurrency_ItAggregatesErrorsFromEverySource(t *testing.T) {
+	errFirst := errors.New("first failed")
+	errThird := errors.New("third failed")
+
+	config := New()
+	config.SetSourceConcurrency(0)
+	config.AddSource(SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		return nil, errFirst
+	}))
+	config.AddSource(namedSource{name: "s2", values: map[string]interface{}{"t1": 1}})
+	config.AddSource(SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		return nil, errThird
+	}))
+
+	err := config.Parse()
+
+	assert.Equal(t, ParseErrors{
+		SourceError{Source: "source #3 (configr.SourceAdapter)", Err: errThird},
+		SourceError{Source: "source #1 (configr.SourceAdapter)", Err: errFirst},
+	}, err)
+}
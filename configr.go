@@ -3,11 +3,15 @@
 package configr
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -18,6 +22,10 @@ import (
 const (
 	tagKeyName  = "configr"
 	tagRequired = "required"
+	tagSecret   = "secret"
+
+	DefaultDescriptionTagName = "desc"
+	DefaultExampleTagName     = "example"
 )
 
 type Manager interface {
@@ -57,6 +65,14 @@ type Config interface {
 	Bool(string) (bool, error)
 	Int(string) (int, error)
 	Float64(string) (float64, error)
+	Int64(string) (int64, error)
+	Uint(string) (uint, error)
+	Uint64(string) (uint64, error)
+	Duration(string) (time.Duration, error)
+	Time(string) (time.Time, error)
+	StringSlice(string) ([]string, error)
+	IntSlice(string) ([]int, error)
+	StringMapString(string) (map[string]string, error)
 
 	Unmarshal(interface{}) error
 	UnmarshalKey(string, interface{}) error
@@ -69,6 +85,18 @@ type Source interface {
 	Unmarshal([]string, KeySplitter) (map[string]interface{}, error)
 }
 
+// Named is implemented by Sources which can identify themselves, e.g. a File
+// reports its path. The name is used when explaining where values came from.
+type Named interface {
+	Name() string
+}
+
+// KeyLocator is implemented by Sources which can describe exactly where an
+// individual key was read from, e.g. the environment variable name.
+type KeyLocator interface {
+	Locate(string, KeySplitter) string
+}
+
 // Encoder would be used to encode registered and required values (along with
 // their defaults or descriptions) into bytes.
 type Encoder interface {
@@ -82,15 +110,25 @@ func NewValidationError(key string, err error) ValidationError {
 	}
 }
 
+// ValidationError is returned when a validator rejects a key's value, Source
+// names the source the value came from (empty for values set directly).
 type ValidationError struct {
-	Key string
-	Err error
+	Key    string
+	Source string
+	Err    error
 }
 
 func (v ValidationError) Error() string {
+	if v.Source != "" {
+		return "Validation error on key '" + v.Key + "' from source '" + v.Source + "': " + v.Err.Error()
+	}
 	return "Validation error on key '" + v.Key + "': " + v.Err.Error()
 }
 
+func (v ValidationError) Unwrap() error {
+	return v.Err
+}
+
 type InvalidTypeError struct {
 	Type reflect.Type
 }
@@ -107,31 +145,110 @@ func (e InvalidTypeError) Error() string {
 	return "configr: Invalid type (nil " + e.Type.String() + ")"
 }
 
+// Configr is safe for concurrent use. Reads are lock free, they are served
+// from an immutable tree of values that is atomically swapped whenever
+// registration, Parse() or a reload produces new values. Registration and
+// parsing are serialised.
 type Configr struct {
+	mu      sync.Mutex
+	current atomic.Value
+
 	valueValidators    map[string][]Validator
 	registeredKeys     map[string]string
+	keyOrder           []string
+	keyTypes           map[string]reflect.Type
 	requiredKeys       map[string]struct{}
 	defaultValues      map[string]interface{}
-	cache              map[string]interface{}
+	examples           map[string]string
 	sources            []Source
-	parsed             bool
+	sourceCalls        []*sourceCalls
 	keyDelimeter       string
 	descriptionWrapper string
 	isCaseInsensitive  bool
+	isStrict           bool
+	isInterpolating    bool
+	secretKeys         map[string]struct{}
+	deprecations       map[string]deprecation
+	profile            string
+	profiles           map[string]struct{}
 	keySplitterFn      KeySplitter
+	descriptionTagName string
+	exampleTagName     string
+
+	sourceConcurrency   int
+	watchInterval       time.Duration
+	changeHandlers      []ChangeHandler
+	reloadErrorHandlers []func(error)
+	warningHandlers     []WarningHandler
 }
 
 func New() *Configr {
-	return &Configr{
+	c := &Configr{
 		valueValidators:    make(map[string][]Validator),
 		registeredKeys:     make(map[string]string),
+		keyTypes:           make(map[string]reflect.Type),
 		requiredKeys:       make(map[string]struct{}),
 		defaultValues:      make(map[string]interface{}),
-		cache:              make(map[string]interface{}),
+		examples:           make(map[string]string),
+		deprecations:       make(map[string]deprecation),
+		profiles:           make(map[string]struct{}),
 		keyDelimeter:       ".",
 		descriptionWrapper: "***",
 		keySplitterFn:      NewKeySplitter("."),
+		descriptionTagName: DefaultDescriptionTagName,
+		exampleTagName:     DefaultExampleTagName,
+		sourceConcurrency:  1,
+		watchInterval:      DefaultWatchInterval,
 	}
+	c.storeTree(&tree{values: make(map[string]interface{})})
+
+	return c
+}
+
+// tree holds a set of values along with the settings needed to look keys up
+// in them. Once stored in a Configr a tree is never modified, writers copy
+// its values, modify the copy and store a new tree.
+type tree struct {
+	values            map[string]interface{}
+	origins           map[string][]Origin
+	warnings          []Warning
+	parsed            bool
+	keyDelimeter      string
+	isCaseInsensitive bool
+	secretKeys        map[string]struct{}
+	// interpolatedSecrets holds keys whose values were interpolated from
+	// secret keys, they're redacted as if they were secret themselves
+	interpolatedSecrets map[string]struct{}
+	// uninterpolated holds the values of interpolated keys as they were
+	// before interpolation, Parse() interpolates them again rather than the
+	// resolved values
+	uninterpolated map[string]interface{}
+}
+
+func (c *Configr) load() *tree {
+	return c.current.Load().(*tree)
+}
+
+// store swaps in new values keeping the current origins, must be called with
+// c.mu held
+func (c *Configr) store(values map[string]interface{}, parsed bool) {
+	c.storeTree(&tree{
+		values:              values,
+		origins:             c.load().origins,
+		warnings:            c.load().warnings,
+		interpolatedSecrets: c.load().interpolatedSecrets,
+		uninterpolated:      c.load().uninterpolated,
+		parsed:              parsed,
+	})
+}
+
+// storeTree swaps in a new tree, must be called with c.mu held
+func (c *Configr) storeTree(t *tree) {
+	t.keyDelimeter = c.keyDelimeter
+	t.isCaseInsensitive = c.isCaseInsensitive
+	t.secretKeys = c.secretKeys
+
+	c.current.Store(t)
 }
 
 func GetConfigr() *Configr {
@@ -166,14 +283,26 @@ func RegisterKey(name, description string, defaultVal interface{}, validators ..
 	globalConfigr.RegisterKey(name, description, defaultVal, validators...)
 }
 func (c *Configr) RegisterKey(name, description string, defaultVal interface{}, validators ...Validator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.registerKey(name, description, defaultVal, validators...)
+}
+
+func (c *Configr) registerKey(name, description string, defaultVal interface{}, validators ...Validator) {
 	if c.isCaseInsensitive {
 		name = strings.ToLower(name)
 	}
+	if _, found := c.registeredKeys[name]; !found {
+		c.keyOrder = append(c.keyOrder, name)
+	}
 	c.registeredKeys[name] = description
 
 	if defaultVal != nil {
 		c.defaultValues[name] = defaultVal
-		c.cache = c.mergeMap(name, defaultVal, c.cache)
+
+		current := c.load()
+		c.store(c.mergeMap(name, defaultVal, copyMap(current.values)), current.parsed)
 	}
 
 	if len(validators) > 0 {
@@ -188,11 +317,18 @@ func RequireKey(name, description string, validators ...Validator) {
 	globalConfigr.RequireKey(name, description, validators...)
 }
 func (c *Configr) RequireKey(name, description string, validators ...Validator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requireKey(name, description, validators...)
+}
+
+func (c *Configr) requireKey(name, description string, validators ...Validator) {
 	if c.isCaseInsensitive {
 		name = strings.ToLower(name)
 	}
 	c.requiredKeys[name] = struct{}{}
-	c.RegisterKey(name, description, nil, validators...)
+	c.registerKey(name, description, nil, validators...)
 }
 
 // AddSource registers Sources with the Configr instance to Unmarshal()
@@ -204,12 +340,16 @@ func AddSource(p Source) {
 	globalConfigr.AddSource(p)
 }
 func (c *Configr) AddSource(p Source) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.sources = append(c.sources, p)
+	c.sourceCalls = append(c.sourceCalls, &sourceCalls{})
 }
 
 // Parse calls Unmarshal on all registered sources, and caches the subsequent
 // key/value's. Additional calls to Parse can be made to add additional config
-// from sources.
+// from sources, use Reload() to rebuild the values from scratch instead.
 //
 // Sources are called in a FILO order, meaning the first source added is
 // considered the highest priority, any keys set from lower priority sources
@@ -218,57 +358,148 @@ func Parse() error {
 	return globalConfigr.Parse()
 }
 func (c *Configr) Parse() error {
-	if err := c.populateValues(); err != nil {
-		return err
+	return c.ParseContext(context.Background())
+}
+
+// ParseContext follows the same rules as Parse(), ctx is passed to every
+// ContextSource and Parse() stops waiting on other sources once ctx is done
+func ParseContext(ctx context.Context) error {
+	return globalConfigr.ParseContext(ctx)
+}
+func (c *Configr) ParseContext(ctx context.Context) error {
+	c.mu.Lock()
+	current := c.load()
+	values := copyMap(current.values)
+	for _, key := range sortedKeys(current.uninterpolated) {
+		c.mergeMap(key, current.uninterpolated[key], values)
 	}
+	state := newParseState(values)
+	defer func() { c.unlockAndWarn(state.warnings) }()
 
-	if err := c.checkRequiredKeys(); err != nil {
+	if err := c.parseInto(ctx, state); err != nil {
 		return err
 	}
 
-	c.parsed = true
+	c.storeTree(state.tree())
 	return nil
 }
 
-func (c *Configr) checkRequiredKeys() error {
+// parseState holds everything produced while parsing sources into values
+type parseState struct {
+	values              map[string]interface{}
+	origins             map[string][]Origin
+	warnings            []Warning
+	errs                []error
+	interpolatedSecrets map[string]struct{}
+	uninterpolated      map[string]interface{}
+
+	// Sources setting each deprecated key, and each replacement key directly
+	deprecatedKeySources map[string]string
+	newKeySources        map[string]string
+}
+
+func newParseState(values map[string]interface{}) *parseState {
+	return &parseState{
+		values:               values,
+		origins:              make(map[string][]Origin),
+		deprecatedKeySources: make(map[string]string),
+		newKeySources:        make(map[string]string),
+	}
+}
+
+func (p *parseState) tree() *tree {
+	return &tree{
+		values:              p.values,
+		origins:             p.origins,
+		warnings:            p.warnings,
+		interpolatedSecrets: p.interpolatedSecrets,
+		uninterpolated:      p.uninterpolated,
+		parsed:              true,
+	}
+}
+
+// parseInto populates values from every source and checks required keys,
+// carrying on past any errors so they can all be returned as ParseErrors.
+func (c *Configr) parseInto(ctx context.Context, state *parseState) error {
+	c.populateValues(ctx, state)
+
+	if c.isInterpolating {
+		c.interpolateValues(state)
+	}
+
+	if err := c.checkRequiredKeys(state.values); err != nil {
+		state.errs = append(state.errs, err)
+	}
+
+	if len(state.errs) > 0 {
+		return ParseErrors(state.errs)
+	}
+
+	return nil
+}
+
+func (c *Configr) checkRequiredKeys(values map[string]interface{}) error {
 	missingKeys := []string{}
 
 	for requiredKey := range c.requiredKeys {
-		if _, err := c.get(requiredKey); err != nil {
+		if _, err := c.getFrom(values, requiredKey); err != nil {
 			missingKeys = append(missingKeys, requiredKey)
 		}
 	}
 
 	if len(missingKeys) > 0 {
+		sort.Strings(missingKeys)
 		return ErrRequiredKeysMissing(missingKeys)
 	}
 
 	return nil
 }
 
-func (c *Configr) populateValues() error {
-	expectedKeys := make([]string, 0, len(c.registeredKeys))
+func (c *Configr) populateValues(ctx context.Context, state *parseState) {
+	profile := c.activeProfile()
+
+	expectedKeys := make([]string, 0, len(c.registeredKeys)+len(c.deprecations))
 	for key, _ := range c.registeredKeys {
 		expectedKeys = append(expectedKeys, key)
+		if profile != "" {
+			expectedKeys = append(expectedKeys, profile+c.keyDelimeter+key)
+		}
+	}
+	for key := range c.deprecations {
+		expectedKeys = append(expectedKeys, key)
 	}
 	sort.Strings(expectedKeys)
 
+	results := c.fetchSources(ctx, expectedKeys)
+
 	for i := len(c.sources) - 1; i >= 0; i-- {
 		source := c.sources[i]
 
-		sourceValues, err := source.Unmarshal(expectedKeys, c.keySplitterFn)
+		name := sourceName(source, i)
+
+		sourceValues, err := results[i].values, results[i].err
+		if skipped, ok := err.(skippedSourceError); ok {
+			state.warnings = append(state.warnings, Warning{Source: name, Message: skipped.Error()})
+			continue
+		}
 		if err != nil {
-			return err
+			state.errs = append(state.errs, newSourceError(name, err))
+			continue
 		}
 
-		for key, value := range sourceValues {
-			if err := c.set(key, value); err != nil {
-				return err
-			}
+		sourceValues = c.applyProfile(profile, sourceValues)
+		sourceValues = c.migrateDeprecatedKeys(state, name, sourceValues)
+		c.recordOrigins(state.origins, name, source, sourceValues)
+
+		if c.isStrict {
+			state.errs = append(state.errs, c.checkUnknownKeys(name, sourceValues)...)
 		}
-	}
 
-	return nil
+		for _, key := range sortedKeys(sourceValues) {
+			errs := c.setIn(state.values, key, sourceValues[key])
+			state.errs = append(state.errs, attributeErrors(name, errs)...)
+		}
+	}
 }
 
 // MustParse wraps Parse() and will panic if there are any resulting errors
@@ -282,20 +513,38 @@ func (c *Configr) MustParse() {
 }
 
 func (c *Configr) set(key string, value interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current := c.load()
+	values := copyMap(current.values)
+	if errs := c.setIn(values, key, value); len(errs) > 0 {
+		if len(errs) == 1 {
+			return errs[0]
+		}
+		return ParseErrors(errs)
+	}
+
+	c.store(values, current.parsed)
+	return nil
+}
+
+// setIn converts the value to its declared type, validates it and merges it
+// into values. The value is merged even if it fails conversion or validation
+// so later checks (such as required keys) see it.
+func (c *Configr) setIn(values map[string]interface{}, key string, value interface{}) []error {
 	if c.isCaseInsensitive {
 		key = strings.ToLower(key)
 	}
-	if err := c.runValidators(key, value); err != nil {
-		return err
-	}
+	value, errs := c.coerceAndValidate(key, value)
 
-	c.cache = c.mergeMap(key, value, c.cache)
+	c.mergeMap(key, value, values)
 
-	return nil
+	return errs
 }
 
 func (c *Configr) mergeMap(key string, value interface{}, targetMap map[string]interface{}) map[string]interface{} {
-	if reflect.TypeOf(value).Kind() == reflect.Map {
+	if isSubtree(value) {
 		targetMap = c.traverseSubMap(key, cast.ToStringMap(value), targetMap)
 	} else {
 		path := strings.SplitN(key, c.keyDelimeter, 2)
@@ -312,8 +561,20 @@ func (c *Configr) mergeMap(key string, value interface{}, targetMap map[string]i
 	return targetMap
 }
 
+// isSubtree reports whether value holds nested keys, maps of a specific type
+// (e.g. a map[string]string declared for a key) are values in their own right
+func isSubtree(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, map[interface{}]interface{}:
+		return true
+	}
+
+	return false
+}
+
 func (c *Configr) traverseKeyPath(currentKey, keyRemainder string, value interface{}, targetMap map[string]interface{}) map[string]interface{} {
-	if _, found := targetMap[currentKey]; !found {
+	// Leaves (including typed maps) are replaced by the subtree
+	if _, isMap := targetMap[currentKey].(map[string]interface{}); !isMap {
 		targetMap[currentKey] = make(map[string]interface{})
 	}
 
@@ -324,7 +585,7 @@ func (c *Configr) traverseKeyPath(currentKey, keyRemainder string, value interfa
 
 func (c *Configr) traverseSubMap(key string, value map[string]interface{}, targetMap map[string]interface{}) map[string]interface{} {
 	for subKey, subValue := range value {
-		if _, found := targetMap[key]; !found {
+		if _, isMap := targetMap[key].(map[string]interface{}); !isMap {
 			targetMap[key] = make(map[string]interface{})
 		}
 		targetMap[key] = c.mergeMap(subKey, subValue, targetMap[key].(map[string]interface{}))
@@ -333,28 +594,37 @@ func (c *Configr) traverseSubMap(key string, value map[string]interface{}, targe
 	return targetMap
 }
 
-func (c *Configr) runValidators(key string, value interface{}) error {
+// runValidators returns the first failing validator of every key found in
+// value
+func (c *Configr) runValidators(key string, value interface{}) []error {
 	keysAndValues, err := c.findKeysAndValuesToValidate(key, value)
 	if err != nil {
-		return err
+		return []error{err}
 	}
 
-	for validatorKey, valueToValidate := range keysAndValues {
+	var errs []error
+	for _, validatorKey := range sortedKeys(keysAndValues) {
 		if validators, found := c.valueValidators[validatorKey]; found {
+			if c.isInterpolating && hasReferences(keysAndValues[validatorKey]) {
+				// Validated once resolved by interpolateValues
+				continue
+			}
 			for _, validate := range validators {
-				if err := validate(valueToValidate); err != nil {
-					return NewValidationError(validatorKey, err)
+				if err := validate(keysAndValues[validatorKey]); err != nil {
+					err = c.load().redactError(validatorKey, keysAndValues[validatorKey], err)
+					errs = append(errs, NewValidationError(validatorKey, err))
+					break
 				}
 			}
 		}
 	}
 
-	return nil
+	return errs
 }
 
 func (c *Configr) findKeysAndValuesToValidate(key string, value interface{}) (map[string]interface{}, error) {
 	keysAndValues := make(map[string]interface{})
-	if reflect.TypeOf(value).Kind() == reflect.Map {
+	if isSubtree(value) {
 		for validatorKey := range c.valueValidators {
 			if !strings.HasPrefix(validatorKey, key) {
 				continue
@@ -379,23 +649,31 @@ func Get(key string) (interface{}, error) {
 	return globalConfigr.Get(key)
 }
 func (c *Configr) Get(key string) (interface{}, error) {
-	if !c.Parsed() {
+	return c.Snapshot().Get(key)
+}
+
+func (t *tree) get(key string) (interface{}, error) {
+	if t == nil || !t.parsed {
 		return nil, ErrParseHasntBeenCalled
 	}
 
-	return c.get(key)
+	return lookupKey(t.values, key, t.keyDelimeter, t.isCaseInsensitive)
 }
 
-func (c *Configr) get(key string) (interface{}, error) {
-	if c.isCaseInsensitive {
+func (c *Configr) getFrom(values map[string]interface{}, key string) (interface{}, error) {
+	return lookupKey(values, key, c.keyDelimeter, c.isCaseInsensitive)
+}
+
+func lookupKey(values map[string]interface{}, key, keyDelimeter string, isCaseInsensitive bool) (interface{}, error) {
+	if isCaseInsensitive {
 		key = strings.ToLower(key)
 	}
-	if value, found := c.cache[key]; found {
+	if value, found := values[key]; found {
 		return value, nil
 	}
 
-	path := strings.Split(key, c.keyDelimeter)
-	parent, found := c.cache[path[0]]
+	path := strings.Split(key, keyDelimeter)
+	parent, found := values[path[0]]
 	if found {
 		if reflect.TypeOf(parent).Kind() == reflect.Map {
 			if val := searchMap(cast.ToStringMap(parent), path[1:]); val != nil {
@@ -407,6 +685,16 @@ func (c *Configr) get(key string) (interface{}, error) {
 	return nil, ErrKeyNotFound
 }
 
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
 // From github.com/spf13/viper
 func searchMap(source map[string]interface{}, path []string) interface{} {
 	if len(path) == 0 {
@@ -433,11 +721,7 @@ func String(key string) (string, error) {
 	return globalConfigr.String(key)
 }
 func (c *Configr) String(key string) (string, error) {
-	val, err := c.Get(key)
-	if err != nil {
-		return "", err
-	}
-	return cast.ToStringE(val)
+	return c.Snapshot().String(key)
 }
 
 // Bool wraps Get() and will attempt to cast the resulting value to a bool
@@ -446,11 +730,7 @@ func Bool(key string) (bool, error) {
 	return globalConfigr.Bool(key)
 }
 func (c *Configr) Bool(key string) (bool, error) {
-	val, err := c.Get(key)
-	if err != nil {
-		return false, err
-	}
-	return cast.ToBoolE(val)
+	return c.Snapshot().Bool(key)
 }
 
 // Int wraps Get() and will attempt to cast the resulting value to a int
@@ -459,11 +739,7 @@ func Int(key string) (int, error) {
 	return globalConfigr.Int(key)
 }
 func (c *Configr) Int(key string) (int, error) {
-	val, err := c.Get(key)
-	if err != nil {
-		return 0, err
-	}
-	return cast.ToIntE(val)
+	return c.Snapshot().Int(key)
 }
 
 // Float64 wraps Get() and will attempt to cast the resulting value to a float64
@@ -472,11 +748,80 @@ func Float64(key string) (float64, error) {
 	return globalConfigr.Float64(key)
 }
 func (c *Configr) Float64(key string) (float64, error) {
-	val, err := c.Get(key)
-	if err != nil {
-		return 0, err
-	}
-	return cast.ToFloat64E(val)
+	return c.Snapshot().Float64(key)
+}
+
+// Int64 wraps Get() and will attempt to cast the resulting value to an int64
+// or error
+func Int64(key string) (int64, error) {
+	return globalConfigr.Int64(key)
+}
+func (c *Configr) Int64(key string) (int64, error) {
+	return c.Snapshot().Int64(key)
+}
+
+// Uint wraps Get() and will attempt to cast the resulting value to a uint
+// or error
+func Uint(key string) (uint, error) {
+	return globalConfigr.Uint(key)
+}
+func (c *Configr) Uint(key string) (uint, error) {
+	return c.Snapshot().Uint(key)
+}
+
+// Uint64 wraps Get() and will attempt to cast the resulting value to a uint64
+// or error
+func Uint64(key string) (uint64, error) {
+	return globalConfigr.Uint64(key)
+}
+func (c *Configr) Uint64(key string) (uint64, error) {
+	return c.Snapshot().Uint64(key)
+}
+
+// Duration wraps Get() and will attempt to cast the resulting value to a
+// time.Duration or error, strings are parsed as durations e.g. "30s"
+func Duration(key string) (time.Duration, error) {
+	return globalConfigr.Duration(key)
+}
+func (c *Configr) Duration(key string) (time.Duration, error) {
+	return c.Snapshot().Duration(key)
+}
+
+// Time wraps Get() and will attempt to cast the resulting value to a time.Time
+// or error, strings are parsed using common formats including RFC3339
+func Time(key string) (time.Time, error) {
+	return globalConfigr.Time(key)
+}
+func (c *Configr) Time(key string) (time.Time, error) {
+	return c.Snapshot().Time(key)
+}
+
+// StringSlice wraps Get() and will attempt to cast the resulting value to a
+// []string or error, strings are split on commas e.g. "a, b"
+func StringSlice(key string) ([]string, error) {
+	return globalConfigr.StringSlice(key)
+}
+func (c *Configr) StringSlice(key string) ([]string, error) {
+	return c.Snapshot().StringSlice(key)
+}
+
+// IntSlice wraps Get() and will attempt to cast the resulting value to a
+// []int or error, strings are split on commas e.g. "1, 2"
+func IntSlice(key string) ([]int, error) {
+	return globalConfigr.IntSlice(key)
+}
+func (c *Configr) IntSlice(key string) ([]int, error) {
+	return c.Snapshot().IntSlice(key)
+}
+
+// StringMapString wraps Get() and will attempt to cast the resulting value to
+// a map[string]string or error, strings are parsed as a JSON object or comma
+// separated pairs e.g. "a=1, b=2"
+func StringMapString(key string) (map[string]string, error) {
+	return globalConfigr.StringMapString(key)
+}
+func (c *Configr) StringMapString(key string) (map[string]string, error) {
+	return c.Snapshot().StringMapString(key)
 }
 
 // Parsed lets the caller know if a Parse() call has been made or not
@@ -484,7 +829,7 @@ func Parsed() bool {
 	return globalConfigr.Parsed()
 }
 func (c *Configr) Parsed() bool {
-	return c.parsed
+	return c.Snapshot().Parsed()
 }
 
 // GenerateBlank generates a 'blank' configuration using the passed Encoder,
@@ -494,35 +839,86 @@ func GenerateBlank(e Encoder) ([]byte, error) {
 	return globalConfigr.GenerateBlank(e)
 }
 func (c *Configr) GenerateBlank(e Encoder) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	blankMap, err := c.blankMap()
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return e.Marshal(blankMap)
+}
+
+func (c *Configr) blankMap() (map[string]interface{}, error) {
 	if len(c.registeredKeys) == 0 {
-		return []byte{}, ErrNoRegisteredValues
+		return nil, ErrNoRegisteredValues
 	}
 
 	blankMap := make(map[string]interface{})
 	for key, description := range c.registeredKeys {
-		if defaultValue, found := c.defaultValues[key]; found {
+		// Secret keys are described rather than given their default
+		if defaultValue, found := c.defaultValues[key]; found && !c.load().isSecret(key) {
 			blankMap = c.mergeMap(key, defaultValue, blankMap)
 		} else {
-			blankMap = c.mergeMap(key, c.wrapDescription(description), blankMap)
+			blankMap = c.mergeMap(key, c.wrapDescription(description, c.examples[key]), blankMap)
 		}
 	}
 
-	return e.Marshal(blankMap)
+	return blankMap, nil
 }
 
-func (c *Configr) wrapDescription(description string) string {
+func (c *Configr) wrapDescription(description, example string) string {
+	if example != "" {
+		description = strings.TrimSpace(description + " e.g. " + example)
+	}
+
 	return strings.Join([]string{c.descriptionWrapper, description, c.descriptionWrapper}, " ")
 }
 
 func (c *Configr) SetKeyPathDelimeter(delimeter string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.keyDelimeter = delimeter
 	c.keySplitterFn = NewKeySplitter(delimeter)
+
+	current := c.load()
+	c.store(current.values, current.parsed)
 }
 func (c *Configr) SetDescriptionWrapper(wrapper string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.descriptionWrapper = wrapper
 }
+
 func (c *Configr) SetIsCaseSensitive(isCaseSensitive bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.isCaseInsensitive = !isCaseSensitive
+
+	current := c.load()
+	c.store(current.values, current.parsed)
+}
+
+// SetDescriptionTagName changes the struct tag RegisterFromStruct reads key
+// descriptions from, defaults to "desc"
+func (c *Configr) SetDescriptionTagName(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.descriptionTagName = name
+}
+
+// SetExampleTagName changes the struct tag RegisterFromStruct reads example
+// values from, defaults to "example"
+func (c *Configr) SetExampleTagName(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.exampleTagName = name
 }
 
 // Unmarshals all parsed values into struct, uses `configr` struct tag for
@@ -545,7 +941,11 @@ func UnmarshalKey(key string, destination interface{}) error {
 	return globalConfigr.UnmarshalKey(key, destination)
 }
 func (c *Configr) UnmarshalKey(key string, destination interface{}) error {
-	if !c.Parsed() {
+	return c.Snapshot().UnmarshalKey(key, destination)
+}
+
+func (t *tree) unmarshalKey(key string, destination interface{}) error {
+	if t == nil || !t.parsed {
 		return ErrParseHasntBeenCalled
 	}
 
@@ -562,15 +962,16 @@ func (c *Configr) UnmarshalKey(key string, destination interface{}) error {
 	}
 
 	if key != "" {
-		subTree, err := c.Get(key)
+		subTree, err := t.get(key)
 		if err != nil {
 			return err
 		}
 
-		return decoder.Decode(subTree)
+		// Decoded from a copy as mapstructure hands back maps by reference
+		return t.redactError(key, subTree, decoder.Decode(copyValue(subTree)))
 	}
 
-	return decoder.Decode(c.cache)
+	return t.redactError("", t.values, decoder.Decode(copyMap(t.values)))
 }
 
 func RegisterFromStruct(structPtr interface{}, fieldToKeyFunc ...NameToKeyFunc) error {
@@ -584,17 +985,22 @@ func (c *Configr) RegisterFromStruct(structPtr interface{}, fieldToKeyFunc ...Na
 		return InvalidTypeError{reflectValue.Type()}
 	}
 
-	c.processFields([]string{}, structPtr, fieldToKeyFunc...)
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	return nil
+	return c.processFields([]string{}, structPtr, fieldToKeyFunc...)
 }
 
-func (c *Configr) processFields(path []string, strut interface{}, fieldToKeyFunc ...NameToKeyFunc) {
+func (c *Configr) processFields(path []string, strut interface{}, fieldToKeyFunc ...NameToKeyFunc) error {
 	reflectType, reflectValue := c.fetchTypeAndValue(strut)
 
 	for i := 0; i < reflectType.NumField(); i++ {
-		c.processField(path, reflectType.Field(i), reflectValue.Field(i), fieldToKeyFunc...)
+		if err := c.processField(path, reflectType.Field(i), reflectValue.Field(i), fieldToKeyFunc...); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
 func (c *Configr) fetchTypeAndValue(strut interface{}) (reflect.Type, reflect.Value) {
@@ -605,23 +1011,69 @@ func (c *Configr) fetchTypeAndValue(strut interface{}) (reflect.Type, reflect.Va
 	return reflect.TypeOf(strut), reflect.ValueOf(strut)
 }
 
-func (c *Configr) processField(path []string, field reflect.StructField, value reflect.Value, fieldToKeyFunc ...NameToKeyFunc) {
+// processField registers a key for the field, `configr` tag options may mark
+// it as required or secret and add validation rules (see RegisterTagRule),
+// `desc` and `example` tags (unless renamed) describe the key:
+//    `configr:"port,required,min=1,max=65535" desc:"Port to listen on" example:"8080"`
+func (c *Configr) processField(path []string, field reflect.StructField, value reflect.Value, fieldToKeyFunc ...NameToKeyFunc) error {
 	tagValue := field.Tag.Get(tagKeyName)
 	tagParts := strings.Split(tagValue, ",")
 
 	name := c.pickFieldName(field.Name, tagParts[0], fieldToKeyFunc...)
-	isRequired := c.isRequiredField(tagParts[1:])
+	isRequired := hasTagOption(tagParts[1:], tagRequired)
+	isSecret := hasTagOption(tagParts[1:], tagSecret)
 
-	if field.Type.Kind() == reflect.Struct {
-		c.processFields(append(path, name), value.Interface(), fieldToKeyFunc...)
+	if field.Type.Kind() == reflect.Struct && !isLeafStruct(field.Type) {
+		if err := c.processFields(append(path, name), value.Interface(), fieldToKeyFunc...); err != nil {
+			return err
+		}
+		if isSecret {
+			c.markSecret(strings.Join(append(path, name), c.keyDelimeter))
+		}
+		return nil
+	}
+
+	validators, err := tagValidators(field, tagParts[1:])
+	if err != nil {
+		return err
+	}
+
+	name = strings.Join(append(path, name), c.keyDelimeter)
+	description := field.Tag.Get(c.descriptionTagName)
+	if isRequired {
+		c.requireKey(name, description, validators...)
 	} else {
-		name = strings.Join(append(path, name), c.keyDelimeter)
-		if isRequired {
-			c.RequireKey(name, "")
-		} else {
-			c.RegisterKey(name, "", value.Interface())
+		c.registerKey(name, description, value.Interface(), validators...)
+	}
+	if c.isCaseInsensitive {
+		name = strings.ToLower(name)
+	}
+	c.keyTypes[name] = field.Type
+	if isSecret {
+		c.markSecret(name)
+	}
+
+	if example := field.Tag.Get(c.exampleTagName); example != "" {
+		c.examples[name] = example
+	}
+
+	return nil
+}
+
+// isLeafStruct reports whether a struct type is a single value rather than a
+// set of keys, such as a time.Time or any struct without exported fields
+func isLeafStruct(t reflect.Type) bool {
+	if t == timeType {
+		return true
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			return false
 		}
 	}
+
+	return true
 }
 
 func (c *Configr) pickFieldName(fieldName string, tagName string, fieldToKeyFunc ...NameToKeyFunc) string {
@@ -640,15 +1092,14 @@ func (c *Configr) pickFieldName(fieldName string, tagName string, fieldToKeyFunc
 	return fieldName
 }
 
-func (c *Configr) isRequiredField(parts []string) bool {
-	var required bool
+func hasTagOption(parts []string, option string) bool {
 	for _, tag := range parts {
-		if tag == tagRequired {
-			required = true
+		if tag == option {
+			return true
 		}
 	}
 
-	return required
+	return false
 }
 
 func NewKeySplitter(delimeter string) KeySplitter {
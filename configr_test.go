@@ -2,7 +2,11 @@ package configr
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,7 +32,7 @@ func addUnMarshalers(m Manager, sources ...Source) {
 	}
 }
 
-func Test_Parse_ItReturnsErrorOnFirstUnMarshalerError(t *testing.T) {
+func Test_Parse_ItReturnsUnMarshalerErrors(t *testing.T) {
 	config := New()
 	s1, s2, s3 := &MockSource{}, &MockSource{}, &MockSource{}
 	setupErroringSources(s1, s2, s3)
@@ -36,7 +40,45 @@ func Test_Parse_ItReturnsErrorOnFirstUnMarshalerError(t *testing.T) {
 
 	err := config.Parse()
 
-	assert.Equal(t, err, errors.New("!"))
+	assert.Equal(t, ParseErrors{SourceError{Source: "source #2 (*configr.MockSource)", Err: errors.New("!")}}, err)
+}
+
+func Test_Parse_ItCollectsAllSourceValidationAndRequiredKeyErrors(t *testing.T) {
+	config := New()
+	sourceErr := errors.New("source failed")
+	config.AddSource(SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		return nil, sourceErr
+	}))
+	config.AddSource(SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		return map[string]interface{}{"t1": 1, "t2": 2}, nil
+	}))
+	config.RegisterKey("t1", "", nil, func(v interface{}) error {
+		return errors.New("t1 is invalid")
+	})
+	config.RegisterKey("t2", "", nil, func(v interface{}) error {
+		return errors.New("t2 is invalid")
+	})
+	config.RequireKey("t3", "")
+	config.RequireKey("t4", "")
+
+	err := config.Parse()
+
+	var validationErr ValidationError
+	var missingErr ErrRequiredKeysMissing
+	assert.True(t, errors.Is(err, sourceErr))
+	assert.True(t, errors.As(err, &validationErr))
+	assert.True(t, errors.As(err, &missingErr))
+	assert.Equal(t, ErrRequiredKeysMissing{"t3", "t4"}, missingErr)
+	assert.EqualError(t, err, `configr: 4 errors parsing configuration:
+  - configr: Error in source 'source #1 (configr.SourceAdapter)': source failed
+  t1:
+    - t1 is invalid (from source #2 (configr.SourceAdapter))
+  t2:
+    - t2 is invalid (from source #2 (configr.SourceAdapter))
+  t3:
+    - required key is missing
+  t4:
+    - required key is missing`)
 }
 
 func Test_Parse_ItDoesntSetUnmarshaldToTrueOnUnMarshalerError(t *testing.T) {
@@ -47,7 +89,7 @@ func Test_Parse_ItDoesntSetUnmarshaldToTrueOnUnMarshalerError(t *testing.T) {
 
 	config.Parse()
 
-	assert.Equal(t, false, config.parsed)
+	assert.Equal(t, false, config.Parsed())
 }
 
 func Test_Parse_ItSetsUnmarshaldToTrueOnSuccessfulParsing(t *testing.T) {
@@ -58,7 +100,7 @@ func Test_Parse_ItSetsUnmarshaldToTrueOnSuccessfulParsing(t *testing.T) {
 
 	config.Parse()
 
-	assert.Equal(t, true, config.parsed)
+	assert.Equal(t, true, config.Parsed())
 }
 
 func Test_MustParse_ItPanicsOnFirstUnMarshalerError(t *testing.T) {
@@ -74,7 +116,7 @@ func Test_set_ItSetsValue(t *testing.T) {
 	config := New()
 
 	assert.NoError(t, config.set("test", 1))
-	assert.Equal(t, 1, config.cache["test"].(int))
+	assert.Equal(t, 1, config.load().values["test"].(int))
 }
 
 func Test_RegisterKey_ItReturnsErrorOnFirstFailingValidator(t *testing.T) {
@@ -165,7 +207,7 @@ func Test_Parse_ItPopulatesConfigrValuesFromSource(t *testing.T) {
 	config.AddSource(s1)
 	config.Parse()
 
-	assert.Equal(t, expectedValues, config.cache)
+	assert.Equal(t, expectedValues, config.load().values)
 }
 
 func Test_AddSource_ItReturnsErrorFromSet(t *testing.T) {
@@ -211,7 +253,7 @@ func Test_Parse_ItOverwritesValuesFromHigherPrioritySources(t *testing.T) {
 	config.AddSource(s2)
 	config.Parse()
 
-	assert.Equal(t, expectedValues, config.cache)
+	assert.Equal(t, expectedValues, config.load().values)
 }
 
 func Test_MustParse_ItDoesntPanicOnValueNotRegisteredErrors(t *testing.T) {
@@ -233,7 +275,7 @@ func Test_MustParse_ItDoesntPanicOnValueNotRegisteredErrors(t *testing.T) {
 
 func Test_Get_ItRetrivesNestedValues(t *testing.T) {
 	config := New()
-	config.cache = map[string]interface{}{
+	config.store(map[string]interface{}{
 		"t1": map[string]interface{}{
 			"t11": 1,
 		},
@@ -245,8 +287,7 @@ func Test_Get_ItRetrivesNestedValues(t *testing.T) {
 		"t3": map[interface{}]interface{}{
 			31: 3,
 		},
-	}
-	config.parsed = true
+	}, true)
 	t1t11Expected := 1
 	t2t21t211Expected := 2
 	t331Expected := 3
@@ -264,6 +305,85 @@ func Test_Get_ItRetrivesNestedValues(t *testing.T) {
 	assert.Equal(t, t331Expected, t331)
 }
 
+func Test_TypedGetters_ItConvertsNativeAndStringValues(t *testing.T) {
+	config := New()
+	config.store(map[string]interface{}{
+		"int64":         "9223372036854775807",
+		"uint":          7,
+		"uint64":        "18446744073709551615",
+		"duration":      "1m30s",
+		"time":          "2020-04-01T10:00:00Z",
+		"stringsCSV":    "a, b,c",
+		"stringsNative": []interface{}{"a", "b"},
+		"intsCSV":       "1, 2",
+		"intsNative":    []interface{}{1, 2.0},
+		"mapPairs":      "a=1, b = 2",
+		"mapJSON":       `{"a": "1"}`,
+		"mapNative":     map[string]interface{}{"a": 1},
+	}, true)
+
+	int64Value, err := config.Int64("int64")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9223372036854775807), int64Value)
+
+	uintValue, err := config.Uint("uint")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), uintValue)
+
+	uint64Value, err := config.Uint64("uint64")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(18446744073709551615), uint64Value)
+
+	duration, err := config.Duration("duration")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, duration)
+
+	timeValue, err := config.Time("time")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC), timeValue.UTC())
+
+	for _, key := range []string{"stringsCSV", "stringsNative"} {
+		values, err := config.StringSlice(key)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, values[:2])
+	}
+
+	for _, key := range []string{"intsCSV", "intsNative"} {
+		ints, err := config.IntSlice(key)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, ints)
+	}
+
+	for _, key := range []string{"mapPairs", "mapJSON", "mapNative"} {
+		m, err := config.StringMapString(key)
+		assert.NoError(t, err)
+		assert.Equal(t, "1", m["a"])
+	}
+}
+
+func Test_TypedGetters_ItReturnsConversionErrorsNamingTheKeyAndType(t *testing.T) {
+	config := New()
+	config.store(map[string]interface{}{
+		"t1": "abc",
+		"t2": "a=1, b",
+	}, true)
+
+	_, err := config.Int("t1")
+	assert.EqualError(t, err, `configr: Unable to convert key 't1' to int: unable to cast "abc" of type string to int`)
+
+	_, err = config.Duration("t1")
+	assert.Equal(t, "time.Duration", err.(ConversionError).Type)
+
+	_, err = config.Uint64("t1")
+	assert.Equal(t, "t1", err.(ConversionError).Key)
+
+	_, err = config.StringMapString("t2")
+	assert.EqualError(t, err, `configr: Unable to convert key 't2' to map[string]string: unable to parse "b" as a key=value pair`)
+
+	_, err = config.IntSlice("missing")
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
 func Test_Parse_ItErrorsIfNotAllRequiredValuesAreFound(t *testing.T) {
 	config := New()
 	s1 := &MockSource{}
@@ -285,7 +405,7 @@ func Test_Parse_ItErrorsIfNotAllRequiredValuesAreFound(t *testing.T) {
 	config.RequireKey("t3.t31", "")
 	config.RequireKey("t4.t41.t411", "")
 
-	assert.Equal(t, ErrRequiredKeysMissing{"t2", "t3.t31"}.Error(), config.Parse().Error())
+	assert.Equal(t, ParseErrors{ErrRequiredKeysMissing{"t2", "t3.t31"}}, config.Parse())
 }
 
 func Test_Parse_ItRespectsNestedValuesFromMultipleSources(t *testing.T) {
@@ -336,7 +456,7 @@ func Test_Parse_ItRespectsNestedValuesFromMultipleSources(t *testing.T) {
 	config.AddSource(s2)
 	config.Parse()
 
-	assert.Equal(t, expectedValues, config.cache)
+	assert.Equal(t, expectedValues, config.load().values)
 }
 
 func Test_set_ItHandlesPathStyleKeysToSetValues(t *testing.T) {
@@ -360,7 +480,7 @@ func Test_set_ItHandlesPathStyleKeysToSetValues(t *testing.T) {
 	assert.NoError(t, config.set("t1.t12.t121", 2))
 	assert.NoError(t, config.set("t2.t21", 3.0))
 
-	assert.Equal(t, expectedValues, config.cache)
+	assert.Equal(t, expectedValues, config.load().values)
 }
 
 func Test_Get_ItErrorsIfYouTryGetBeforeParsing(t *testing.T) {
@@ -373,7 +493,7 @@ func Test_Get_ItErrorsIfYouTryGetBeforeParsing(t *testing.T) {
 
 func Test_Get_ItReturnsDefaultValueIfNoValueFoundFromSources(t *testing.T) {
 	config := New()
-	config.parsed = true
+	config.store(config.load().values, true)
 
 	config.RegisterKey("test", "its a test!", 1)
 
@@ -385,7 +505,7 @@ func Test_Get_ItReturnsDefaultValueIfNoValueFoundFromSources(t *testing.T) {
 
 func Test_Get_ItReturnsDefaultValuesInSubtree(t *testing.T) {
 	config := New()
-	config.parsed = true
+	config.store(config.load().values, true)
 
 	config.RequireKey("t1", "")
 	config.RegisterKey("t1.t1", "", true)
@@ -436,6 +556,26 @@ func Test_GenerateBlank_ItPassesANestedMapOfConfigNamesAndDefaults(t *testing.T)
 	g.AssertExpectations(t)
 }
 
+func Test_GenerateBlank_ItIncludesExamplesInDescriptions(t *testing.T) {
+	config := New()
+	g := &MockGenerator{}
+	expectedValues := map[string]interface{}{
+		"t1": "*** Email from address e.g. my@email.com ***",
+		"t2": "*** e.g. Hello ***",
+	}
+	testStruct := struct {
+		T1 string `configr:"t1,required" desc:"Email from address" example:"my@email.com"`
+		T2 string `configr:"t2,required" example:"Hello"`
+	}{}
+
+	assert.NoError(t, config.RegisterFromStruct(&testStruct))
+	g.On("Marshal", expectedValues).Return([]byte{}, nil)
+
+	config.GenerateBlank(g)
+
+	g.AssertExpectations(t)
+}
+
 func Test_Parse_ItIsCaseSensitiveByDefault(t *testing.T) {
 	config := New()
 	s1 := &MockSource{}
@@ -450,7 +590,7 @@ func Test_Parse_ItIsCaseSensitiveByDefault(t *testing.T) {
 	config.AddSource(s1)
 	config.Parse()
 
-	assert.Equal(t, expectedValues, config.cache)
+	assert.Equal(t, expectedValues, config.load().values)
 }
 
 func Test_Parse_ItIsCaseInensitive(t *testing.T) {
@@ -473,7 +613,7 @@ func Test_Parse_ItIsCaseInensitive(t *testing.T) {
 	config.SetIsCaseSensitive(false)
 	config.Parse()
 
-	assert.Equal(t, expectedValues, config.cache)
+	assert.Equal(t, expectedValues, config.load().values)
 }
 
 func Test_Parse_ItPassesAllKeysToUnmarshalToSource(t *testing.T) {
@@ -537,7 +677,7 @@ func Test_Unmarshal_ItReturnsErrorIfConfigrHasntBeenParsed(t *testing.T) {
 
 func Test_UnmarshalKey_ItReturnsAnyGetErrors(t *testing.T) {
 	config := New()
-	config.parsed = true
+	config.store(config.load().values, true)
 
 	assert.Equal(t, ErrKeyNotFound, config.UnmarshalKey("t1", &struct{}{}))
 }
@@ -649,6 +789,66 @@ func Test_RegisterFromStruct_RegistersRequiredKeysFromPorpoertiesUsingTag(t *tes
 	assert.Equal(t, expectedRequiredKeys, config.requiredKeys)
 }
 
+func Test_RegisterFromStruct_AttachesValidatorsFromTagRules(t *testing.T) {
+	defer func() { delete(RegisteredTagRules, "even") }()
+	config := New()
+	testStruct := struct {
+		T1 int `configr:"t1,even,squash"`
+	}{}
+
+	RegisterTagRule("even", func(arg string, fieldType reflect.Type) (Validator, error) {
+		return func(v interface{}) error {
+			if v.(int)%2 != 0 {
+				return errors.New("must be even")
+			}
+			return nil
+		}, nil
+	})
+
+	assert.NoError(t, config.RegisterFromStruct(&testStruct))
+	assert.NoError(t, config.set("t1", 2))
+	assert.EqualError(t, config.set("t1", 3), "Validation error on key 't1': must be even")
+}
+
+func Test_RegisterFromStruct_ReturnsErrorForUnknownTagRulesWithValues(t *testing.T) {
+	config := New()
+	testStruct := struct {
+		T1 int `configr:"t1,between=1|2"`
+	}{}
+
+	err := config.RegisterFromStruct(&testStruct)
+
+	assert.Equal(t, TagRuleError{"T1", "between=1|2", ErrUnknownTagRule}, err)
+}
+
+func Test_RegisterFromStruct_RegistersDescriptionsAndExamplesFromTags(t *testing.T) {
+	config := New()
+	testStruct := struct {
+		T1 string `configr:",required" desc:"Email from address" example:"my@email.com"`
+		T2 string `configr:",required" example:"Hello"`
+		T3 int    `desc:"Max retries"`
+	}{}
+
+	assert.NoError(t, config.RegisterFromStruct(&testStruct))
+
+	assert.Equal(t, map[string]string{"T1": "Email from address", "T2": "", "T3": "Max retries"}, config.registeredKeys)
+	assert.Equal(t, map[string]string{"T1": "my@email.com", "T2": "Hello"}, config.examples)
+}
+
+func Test_RegisterFromStruct_ReadsDescriptionsAndExamplesFromRenamedTags(t *testing.T) {
+	config := New()
+	config.SetDescriptionTagName("help")
+	config.SetExampleTagName("eg")
+	testStruct := struct {
+		T1 string `configr:",required" help:"Email from address" eg:"my@email.com" desc:"ignored"`
+	}{}
+
+	assert.NoError(t, config.RegisterFromStruct(&testStruct))
+
+	assert.Equal(t, map[string]string{"T1": "Email from address"}, config.registeredKeys)
+	assert.Equal(t, map[string]string{"T1": "my@email.com"}, config.examples)
+}
+
 func Test_RegisterFromStruct_UsesValueInStructPropertyAsDefaultForNonRequired(t *testing.T) {
 	config := New()
 	testStruct := struct {
@@ -770,6 +970,69 @@ func Test_RegisterFromStruct_SupportsEmbeddedStructsWithDefaultValues(t *testing
 	assert.Equal(t, expectedDefaultValues, config.defaultValues)
 }
 
+func Test_RegisterFromStruct_RegistersTimesAndOpaqueStructsAsKeys(t *testing.T) {
+	config := New()
+	testStruct := struct {
+		Started time.Time
+		Opaque  struct {
+			value int
+		}
+	}{}
+
+	expectedRegisteredKeys := map[string]string{
+		"Started": "",
+		"Opaque":  "",
+	}
+
+	assert.NotPanics(t, func() {
+		assert.NoError(t, config.RegisterFromStruct(&testStruct))
+	})
+
+	assert.Equal(t, expectedRegisteredKeys, config.registeredKeys)
+	assert.Equal(t, reflect.TypeOf(time.Time{}), config.keyTypes["Started"])
+}
+
+func Test_Configr_ItIsSafeForConcurrentReadsRegistrationAndParsing(t *testing.T) {
+	config := New()
+	config.RegisterKey("t1.t11", "", 1)
+	config.AddSource(SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		return map[string]interface{}{
+			"t1": map[string]interface{}{"t11": 2},
+		}, nil
+	}))
+	assert.NoError(t, config.Parse())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			value, err := config.Int("t1.t11")
+			assert.NoError(t, err)
+			assert.Equal(t, 2, value)
+		}()
+		go func() {
+			defer wg.Done()
+			destination := struct{ T11 int }{}
+			assert.NoError(t, config.UnmarshalKey("t1", &destination))
+			assert.Equal(t, 2, destination.T11)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			config.RegisterKey(fmt.Sprintf("t2.t%d", i), "", i)
+		}(i)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, config.Parse())
+		}()
+	}
+	wg.Wait()
+
+	value, err := config.Int("t2.t9")
+	assert.NoError(t, err)
+	assert.Equal(t, 9, value)
+}
+
 type MockGenerator struct {
 	mock.Mock
 }
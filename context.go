@@ -0,0 +1,168 @@
+package configr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ContextSource is implemented by Sources which can be cancelled, such as
+// those reading from a network service. ParseContext() calls UnmarshalContext
+// in place of Unmarshal.
+type ContextSource interface {
+	Source
+	UnmarshalContext(context.Context, []string, KeySplitter) (map[string]interface{}, error)
+}
+
+// ContextSourceAdapter allows you to convert a func:
+//    func(context.Context, []string, KeySplitter) (map[string]interface{}, error)
+// into a type that satisfies the ContextSource interface
+type ContextSourceAdapter func(context.Context, []string, KeySplitter) (map[string]interface{}, error)
+
+func (f ContextSourceAdapter) Unmarshal(keys []string, keySplitterFn KeySplitter) (map[string]interface{}, error) {
+	return f(context.Background(), keys, keySplitterFn)
+}
+
+func (f ContextSourceAdapter) UnmarshalContext(ctx context.Context, keys []string, keySplitterFn KeySplitter) (map[string]interface{}, error) {
+	return f(ctx, keys, keySplitterFn)
+}
+
+var _ ContextSource = ContextSourceAdapter(nil)
+
+// sourceCalls tracks the calls made to a source which can't be cancelled. A
+// call abandoned once its context was done keeps running in the background,
+// sources aren't expected to be safe for concurrent use so the source isn't
+// unmarshalled again, or polled for changes, until that call has finished.
+type sourceCalls struct {
+	mu      sync.Mutex
+	running chan struct{}
+}
+
+// wait blocks until the previous call has finished or ctx is done
+func (s *sourceCalls) wait(ctx context.Context) error {
+	s.mu.Lock()
+	running := s.running
+	s.mu.Unlock()
+
+	if running == nil {
+		return nil
+	}
+
+	select {
+	case <-running:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// start records a call as running until the returned func is called
+func (s *sourceCalls) start() func() {
+	running := make(chan struct{})
+
+	s.mu.Lock()
+	s.running = running
+	s.mu.Unlock()
+
+	return func() { close(running) }
+}
+
+func (s *sourceCalls) busy() bool {
+	s.mu.Lock()
+	running := s.running
+	s.mu.Unlock()
+
+	if running == nil {
+		return false
+	}
+
+	select {
+	case <-running:
+		return false
+	default:
+		return true
+	}
+}
+
+// unmarshalSource unmarshals a source honouring ctx. Sources which aren't
+// ContextSources can't be cancelled, once ctx is done they are left to finish
+// in the background and their result is discarded, calls tracks them so the
+// source is only ever called once at a time.
+func unmarshalSource(ctx context.Context, source Source, calls *sourceCalls, keys []string, keySplitter KeySplitter) (map[string]interface{}, error) {
+	if err := calls.wait(ctx); err != nil {
+		return map[string]interface{}{}, err
+	}
+
+	if contextSource, ok := source.(ContextSource); ok {
+		return contextSource.UnmarshalContext(ctx, keys, keySplitter)
+	}
+
+	if ctx.Done() == nil {
+		return source.Unmarshal(keys, keySplitter)
+	}
+
+	type result struct {
+		values map[string]interface{}
+		err    error
+	}
+	results := make(chan result, 1)
+	finished := calls.start()
+	go func() {
+		defer finished()
+		values, err := source.Unmarshal(keys, keySplitter)
+		results <- result{values, err}
+	}()
+
+	select {
+	case r := <-results:
+		return r.values, r.err
+	case <-ctx.Done():
+		return map[string]interface{}{}, ctx.Err()
+	}
+}
+
+// Timeout wraps a source so that unmarshalling it is abandoned, failing
+// Parse() (unless also wrapped with Optional()), when it takes longer than
+// timeout. Sources that aren't ContextSources can't be stopped, they're left
+// running in the background and the next Parse() waits (up to timeout) for
+// them to finish rather than calling them concurrently.
+func Timeout(source Source, timeout time.Duration) Source {
+	return &timeoutSource{Source: source, timeout: timeout}
+}
+
+type timeoutSource struct {
+	Source
+	timeout time.Duration
+	calls   sourceCalls
+}
+
+func (t *timeoutSource) Unmarshal(keys []string, keySplitter KeySplitter) (map[string]interface{}, error) {
+	return t.UnmarshalContext(context.Background(), keys, keySplitter)
+}
+
+func (t *timeoutSource) UnmarshalContext(ctx context.Context, keys []string, keySplitter KeySplitter) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	values, err := unmarshalSource(ctx, t.Source, &t.calls, keys, keySplitter)
+	if err == context.DeadlineExceeded {
+		return values, fmt.Errorf("configr: Source timed out after %s: %w", t.timeout, err)
+	}
+
+	return values, err
+}
+
+// Changed satisfies the Watchable interface for wrapped sources that do, a
+// source still running in the background isn't polled
+func (t *timeoutSource) Changed() (bool, error) {
+	if watchable, ok := t.Source.(Watchable); ok && !t.calls.busy() {
+		return watchable.Changed()
+	}
+
+	return false, nil
+}
+
+func (t *timeoutSource) unwrap() Source {
+	return t.Source
+}
@@ -0,0 +1,125 @@
+package configr
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey struct{}
+
+func slowSource(delay time.Duration) Source {
+	return SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		time.Sleep(delay)
+		return map[string]interface{}{"slow": true}, nil
+	})
+}
+
+func Test_ParseContext_ItPassesTheContextToContextSources(t *testing.T) {
+	var received interface{}
+
+	config := New()
+	config.AddSource(ContextSourceAdapter(func(ctx context.Context, _ []string, _ KeySplitter) (map[string]interface{}, error) {
+		received = ctx.Value(contextKey{})
+		return map[string]interface{}{"t1": 1}, nil
+	}))
+
+	assert.NoError(t, config.ParseContext(context.WithValue(context.Background(), contextKey{}, "value")))
+
+	assert.Equal(t, "value", received)
+	value, _ := config.Int("t1")
+	assert.Equal(t, 1, value)
+}
+
+func Test_ParseContext_ItStopsWaitingOnSourcesOnceTheContextIsDone(t *testing.T) {
+	config := New()
+	config.AddSource(slowSource(time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := config.ParseContext(ctx)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, time.Since(start) < time.Second)
+	assert.False(t, config.Parsed())
+}
+
+func Test_Timeout_ItFailsSourcesTakingTooLong(t *testing.T) {
+	config := New()
+	config.AddSource(Timeout(slowSource(time.Second), 10*time.Millisecond))
+	config.AddSource(Timeout(slowSource(0), time.Second))
+
+	err := config.Parse()
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.EqualError(t, err.(ParseErrors)[0], "configr: Error in source 'source #1 (configr.SourceAdapter)': configr: Source timed out after 10ms: context deadline exceeded")
+}
+
+func Test_Timeout_ItCanBeOptional(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"t1": 1}})
+	config.AddSource(Optional(Timeout(namedSource{name: "s2"}, time.Second)))
+	config.AddSource(Optional(Timeout(slowSource(time.Second), 10*time.Millisecond)))
+
+	assert.NoError(t, config.Parse())
+
+	warnings := config.Warnings()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, "source #3 (configr.SourceAdapter)", warnings[0].Source)
+	assert.Equal(t, "optional source skipped: configr: Source timed out after 10ms: context deadline exceeded", warnings[0].Message)
+}
+
+type blockingSource struct {
+	release  chan struct{}
+	calls    int32
+	inFlight int32
+	overlaps int32
+	polls    int32
+}
+
+func (b *blockingSource) Unmarshal(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+	atomic.AddInt32(&b.calls, 1)
+	if atomic.AddInt32(&b.inFlight, 1) > 1 {
+		atomic.AddInt32(&b.overlaps, 1)
+	}
+	defer atomic.AddInt32(&b.inFlight, -1)
+
+	<-b.release
+	return map[string]interface{}{"t1": 1}, nil
+}
+
+func (b *blockingSource) Changed() (bool, error) {
+	atomic.AddInt32(&b.polls, 1)
+	return false, nil
+}
+
+func Test_Timeout_ItWaitsForAbandonedCallsBeforeCallingASourceAgain(t *testing.T) {
+	source := &blockingSource{release: make(chan struct{})}
+	timeout := Timeout(source, time.Minute)
+	config := New()
+	config.AddSource(timeout)
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		assert.True(t, errors.Is(config.ParseContext(ctx), context.DeadlineExceeded))
+		cancel()
+	}
+	changed, err := timeout.(Watchable).Changed()
+	assert.False(t, changed)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&source.calls))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&source.polls))
+
+	close(source.release)
+
+	assert.NoError(t, config.Parse())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&source.calls))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&source.overlaps))
+}
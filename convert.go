@@ -0,0 +1,217 @@
+package configr
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// toStringSlice extends cast.ToStringSliceE by splitting strings on commas, as
+// typically found in environmental variables
+func toStringSlice(v interface{}) ([]string, error) {
+	if s, ok := v.(string); ok {
+		return splitList(s), nil
+	}
+
+	return cast.ToStringSliceE(v)
+}
+
+// toIntSlice extends cast.ToIntSliceE by splitting strings on commas
+func toIntSlice(v interface{}) ([]int, error) {
+	if s, ok := v.(string); ok {
+		return cast.ToIntSliceE(splitList(s))
+	}
+
+	return cast.ToIntSliceE(v)
+}
+
+// toStringMapString extends cast.ToStringMapStringE by parsing strings which
+// aren't JSON objects as comma separated key=value pairs
+func toStringMapString(v interface{}) (map[string]string, error) {
+	s, ok := v.(string)
+	if !ok || strings.HasPrefix(strings.TrimSpace(s), "{") {
+		return cast.ToStringMapStringE(v)
+	}
+
+	m := make(map[string]string)
+	for _, pair := range splitList(s) {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("unable to parse %q as a key=value pair", pair)
+		}
+		m[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return m, nil
+}
+
+func splitList(s string) []string {
+	list := []string{}
+	if strings.TrimSpace(s) == "" {
+		return list
+	}
+
+	for _, item := range strings.Split(s, ",") {
+		list = append(list, strings.TrimSpace(item))
+	}
+
+	return list
+}
+
+// coerce converts v to type t using the same rules as the typed getters,
+// e.g. "30s" to a time.Duration or "a,b" to a []string. Values are returned
+// untouched when t has no sensible conversion (interfaces, structs...).
+func coerce(v interface{}, t reflect.Type) (interface{}, error) {
+	if v == nil || reflect.TypeOf(v) == t {
+		return v, nil
+	}
+
+	switch t {
+	case durationType:
+		return cast.ToDurationE(v)
+	case timeType:
+		return cast.ToTimeE(v)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := cast.ToBoolE(v)
+		return convertTo(b, t), err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if err := checkWhole(v); err != nil {
+			return nil, err
+		}
+		n, err := cast.ToInt64E(v)
+		if err == nil && reflect.Zero(t).OverflowInt(n) {
+			err = fmt.Errorf("%d overflows %s", n, t)
+		}
+		return convertTo(n, t), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if err := checkWhole(v); err != nil {
+			return nil, err
+		}
+		n, err := cast.ToUint64E(v)
+		if err == nil && reflect.Zero(t).OverflowUint(n) {
+			err = fmt.Errorf("%d overflows %s", n, t)
+		}
+		return convertTo(n, t), err
+	case reflect.Float32, reflect.Float64:
+		f, err := cast.ToFloat64E(v)
+		return convertTo(f, t), err
+	case reflect.String:
+		if kind := reflect.TypeOf(v).Kind(); kind == reflect.Map || kind == reflect.Slice {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to %s", v, v, t)
+		}
+		s, err := cast.ToStringE(v)
+		return convertTo(s, t), err
+	case reflect.Slice:
+		return coerceSlice(v, t)
+	case reflect.Map:
+		return coerceMap(v, t)
+	}
+
+	return v, nil
+}
+
+func convertTo(v interface{}, t reflect.Type) interface{} {
+	return reflect.ValueOf(v).Convert(t).Interface()
+}
+
+// checkWhole stops cast silently truncating fractions when converting to
+// integers
+func checkWhole(v interface{}) error {
+	if f, err := cast.ToFloat64E(v); err == nil && f != math.Trunc(f) {
+		if _, isString := v.(string); !isString {
+			return fmt.Errorf("%v is not a whole number", v)
+		}
+	}
+
+	return nil
+}
+
+func coerceSlice(v interface{}, t reflect.Type) (interface{}, error) {
+	var items []interface{}
+
+	if s, ok := v.(string); ok {
+		if t.Elem().Kind() == reflect.Uint8 {
+			return convertTo([]byte(s), t), nil
+		}
+		for _, item := range splitList(s) {
+			items = append(items, item)
+		}
+	} else if reflect.TypeOf(v).Kind() == reflect.Slice {
+		reflected := reflect.ValueOf(v)
+		for i := 0; i < reflected.Len(); i++ {
+			items = append(items, reflected.Index(i).Interface())
+		}
+	} else {
+		return nil, fmt.Errorf("unable to cast %#v of type %T to %s", v, v, t)
+	}
+
+	slice := reflect.MakeSlice(t, len(items), len(items))
+	for i, item := range items {
+		coerced, err := coerce(item, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if coerced == nil {
+			continue
+		}
+		if !reflect.TypeOf(coerced).AssignableTo(t.Elem()) {
+			// Items with no conversion (e.g. structs) are left for Unmarshal()
+			return v, nil
+		}
+		slice.Index(i).Set(reflect.ValueOf(coerced))
+	}
+
+	return slice.Interface(), nil
+}
+
+func coerceMap(v interface{}, t reflect.Type) (interface{}, error) {
+	if t.Key().Kind() != reflect.String {
+		return v, nil
+	}
+
+	var values map[string]interface{}
+	if s, ok := v.(string); ok {
+		pairs, err := toStringMapString(s)
+		if err != nil {
+			return nil, err
+		}
+		values = make(map[string]interface{}, len(pairs))
+		for key, value := range pairs {
+			values[key] = value
+		}
+	} else {
+		var err error
+		if values, err = cast.ToStringMapE(v); err != nil {
+			return nil, err
+		}
+	}
+
+	m := reflect.MakeMapWithSize(t, len(values))
+	for key, value := range values {
+		coerced, err := coerce(value, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if coerced == nil {
+			continue
+		}
+		if !reflect.TypeOf(coerced).AssignableTo(t.Elem()) {
+			return v, nil
+		}
+		m.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), reflect.ValueOf(coerced))
+	}
+
+	return m.Interface(), nil
+}
@@ -0,0 +1,216 @@
+package configr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Warning describes a problem found while parsing that doesn't stop the
+// configuration from being used, such as a deprecated key being set
+type Warning struct {
+	Source  string
+	Key     string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("configr: %s (from %s)", w.Message, w.Source)
+}
+
+type WarningHandler func(Warning)
+
+// OnWarning registers a handler to be called with every warning produced by
+// Parse() or a reload
+func OnWarning(handler WarningHandler) {
+	globalConfigr.OnWarning(handler)
+}
+func (c *Configr) OnWarning(handler WarningHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.warningHandlers = append(c.warningHandlers, handler)
+}
+
+// Warnings returns the warnings produced by the last successful Parse() or
+// reload
+func Warnings() []Warning {
+	return globalConfigr.Warnings()
+}
+func (c *Configr) Warnings() []Warning {
+	return append([]Warning{}, c.load().warnings...)
+}
+
+// unlockAndWarn releases c.mu before passing warnings to the warning handlers,
+// leaving handlers free to call back into the Configr
+func (c *Configr) unlockAndWarn(warnings []Warning) {
+	handlers := c.warningHandlers
+	c.mu.Unlock()
+
+	for _, warning := range warnings {
+		for _, handler := range handlers {
+			handler(warning)
+		}
+	}
+}
+
+type deprecation struct {
+	newKey  string
+	message string
+}
+
+// DeprecatedKeyConflictError is returned when a deprecated key and its
+// replacement are both set. NewKeySource is only set when the replacement
+// comes from a different source to the deprecated key.
+type DeprecatedKeyConflictError struct {
+	Source       string
+	OldKey       string
+	NewKey       string
+	NewKeySource string
+}
+
+func (e DeprecatedKeyConflictError) Error() string {
+	if e.NewKeySource != "" {
+		return fmt.Sprintf("configr: Deprecated key '%s' is set in %s and its replacement '%s' in %s", e.OldKey, e.Source, e.NewKey, e.NewKeySource)
+	}
+	return fmt.Sprintf("configr: Both deprecated key '%s' and its replacement '%s' are set in %s", e.OldKey, e.NewKey, e.Source)
+}
+
+func (e DeprecatedKeyConflictError) errorKey() string {
+	return e.NewKey
+}
+
+func (e DeprecatedKeyConflictError) keyMessage() string {
+	return fmt.Sprintf("also set by deprecated key '%s' in %s", e.OldKey, e.Source)
+}
+
+// DeprecateKey marks oldKey as replaced by newKey. Values found under oldKey
+// (or beneath it) in any source are moved to newKey and reported as a
+// Warning, setting both keys is an error even when they come from different
+// sources. An empty newKey marks
+// oldKey as removed, its values are dropped with a Warning.
+func DeprecateKey(oldKey, newKey, message string) {
+	globalConfigr.DeprecateKey(oldKey, newKey, message)
+}
+func (c *Configr) DeprecateKey(oldKey, newKey, message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.isCaseInsensitive {
+		oldKey, newKey = strings.ToLower(oldKey), strings.ToLower(newKey)
+	}
+	c.deprecations[oldKey] = deprecation{newKey: newKey, message: message}
+}
+
+// migrateDeprecatedKeys moves or removes deprecated keys in a source's values,
+// returning the values untouched when no deprecated keys are found
+func (c *Configr) migrateDeprecatedKeys(state *parseState, name string, sourceValues map[string]interface{}) map[string]interface{} {
+	if len(c.deprecations) == 0 {
+		return sourceValues
+	}
+
+	oldKeys := make([]string, 0, len(c.deprecations))
+	for oldKey := range c.deprecations {
+		oldKeys = append(oldKeys, oldKey)
+	}
+	sort.Strings(oldKeys)
+
+	flattened := flattenMap(sourceValues, c.keyDelimeter)
+	migrated := false
+
+	// Replacements set directly, checked before any migration adds to them
+	for _, oldKey := range oldKeys {
+		d := c.deprecations[oldKey]
+		if d.newKey == "" || len(c.keysUnder(flattened, oldKey)) > 0 || len(c.keysUnder(flattened, d.newKey)) == 0 {
+			continue
+		}
+		if oldSource, found := state.deprecatedKeySources[oldKey]; found {
+			state.errs = append(state.errs, DeprecatedKeyConflictError{Source: oldSource, OldKey: oldKey, NewKey: d.newKey, NewKeySource: name})
+			continue
+		}
+		if _, found := state.newKeySources[d.newKey]; !found {
+			state.newKeySources[d.newKey] = name
+		}
+	}
+
+	for _, oldKey := range oldKeys {
+		d := c.deprecations[oldKey]
+		oldLeaves := c.keysUnder(flattened, oldKey)
+		if len(oldLeaves) == 0 {
+			continue
+		}
+
+		if d.newKey == "" {
+			for _, leaf := range oldLeaves {
+				delete(flattened, leaf)
+			}
+			state.warnings = append(state.warnings, Warning{
+				Source:  name,
+				Key:     oldKey,
+				Message: appendMessage(fmt.Sprintf("key '%s' has been removed and is ignored", oldKey), d.message),
+			})
+			migrated = true
+			continue
+		}
+
+		if len(c.keysUnder(flattened, d.newKey)) > 0 {
+			state.errs = append(state.errs, DeprecatedKeyConflictError{Source: name, OldKey: oldKey, NewKey: d.newKey})
+			continue
+		}
+		if newSource, found := state.newKeySources[d.newKey]; found {
+			state.errs = append(state.errs, DeprecatedKeyConflictError{Source: name, OldKey: oldKey, NewKey: d.newKey, NewKeySource: newSource})
+			continue
+		}
+		if _, found := state.deprecatedKeySources[oldKey]; !found {
+			state.deprecatedKeySources[oldKey] = name
+		}
+
+		for _, leaf := range oldLeaves {
+			flattened[d.newKey+leaf[len(oldKey):]] = flattened[leaf]
+			delete(flattened, leaf)
+		}
+		state.warnings = append(state.warnings, Warning{
+			Source:  name,
+			Key:     oldKey,
+			Message: appendMessage(fmt.Sprintf("key '%s' is deprecated, use '%s' instead", oldKey, d.newKey), d.message),
+		})
+		migrated = true
+	}
+
+	if !migrated {
+		return sourceValues
+	}
+
+	nested := make(map[string]interface{})
+	for _, key := range sortedKeys(flattened) {
+		c.mergeMap(key, flattened[key], nested)
+	}
+
+	return nested
+}
+
+// keysUnder returns the keys in flattened that are key or beneath it
+func (c *Configr) keysUnder(flattened map[string]interface{}, key string) []string {
+	keys := []string{}
+	for flattenedKey := range flattened {
+		compareKey := flattenedKey
+		if c.isCaseInsensitive {
+			compareKey = strings.ToLower(compareKey)
+		}
+
+		if compareKey == key || strings.HasPrefix(compareKey, key+c.keyDelimeter) {
+			keys = append(keys, flattenedKey)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func appendMessage(warning, message string) string {
+	if message == "" {
+		return warning
+	}
+
+	return warning + ": " + message
+}
@@ -0,0 +1,115 @@
+package configr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DeprecateKey_ItMovesValuesToTheNewKey(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"email": map[string]interface{}{"subj": "Hi", "from": "a@b.com"},
+	}})
+	config.RegisterKey("email.subject", "", "")
+	config.RegisterKey("email.from", "", "")
+	config.DeprecateKey("email.subj", "email.subject", "renamed in v2")
+
+	var warnings []Warning
+	config.OnWarning(func(w Warning) { warnings = append(warnings, w) })
+
+	assert.NoError(t, config.Parse())
+
+	subject, err := config.String("email.subject")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hi", subject)
+	from, _ := config.String("email.from")
+	assert.Equal(t, "a@b.com", from)
+
+	expected := []Warning{{
+		Source:  "s1",
+		Key:     "email.subj",
+		Message: "key 'email.subj' is deprecated, use 'email.subject' instead: renamed in v2",
+	}}
+	assert.Equal(t, expected, warnings)
+	assert.Equal(t, expected, config.Warnings())
+	assert.Equal(t, "configr: key 'email.subj' is deprecated, use 'email.subject' instead: renamed in v2 (from s1)", warnings[0].String())
+}
+
+func Test_DeprecateKey_ItMovesNestedValues(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"mail": map[string]interface{}{"subject": "Hi"},
+	}})
+	config.RegisterKey("email.subject", "", "")
+	config.DeprecateKey("mail", "email", "")
+
+	assert.NoError(t, config.Parse())
+
+	subject, _ := config.String("email.subject")
+	assert.Equal(t, "Hi", subject)
+	assert.Equal(t, "key 'mail' is deprecated, use 'email' instead", config.Warnings()[0].Message)
+}
+
+func Test_DeprecateKey_ItDropsRemovedKeys(t *testing.T) {
+	config := New()
+	config.SetStrict(true)
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"legacy": true}})
+	config.DeprecateKey("legacy", "", "no longer needed")
+
+	assert.NoError(t, config.Parse())
+
+	_, err := config.Get("legacy")
+	assert.Equal(t, ErrKeyNotFound, err)
+	assert.Equal(t, []Warning{{
+		Source:  "s1",
+		Key:     "legacy",
+		Message: "key 'legacy' has been removed and is ignored: no longer needed",
+	}}, config.Warnings())
+}
+
+func Test_DeprecateKey_ItErrorsWhenBothKeysAreSetInOneSource(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"email": map[string]interface{}{"subj": "Hi", "subject": "Hello"},
+	}})
+	config.RegisterKey("email.subject", "", "")
+	config.DeprecateKey("email.subj", "email.subject", "")
+
+	err := config.Parse()
+
+	var conflict DeprecatedKeyConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.Equal(t, DeprecatedKeyConflictError{Source: "s1", OldKey: "email.subj", NewKey: "email.subject"}, conflict)
+	assert.EqualError(t, conflict, "configr: Both deprecated key 'email.subj' and its replacement 'email.subject' are set in s1")
+}
+
+func Test_DeprecateKey_ItErrorsWhenBothKeysAreSetInDifferentSources(t *testing.T) {
+	for name, sources := range map[string][]namedSource{
+		"old key in higher priority source": {
+			{name: "s1", values: map[string]interface{}{"old": "a"}},
+			{name: "s2", values: map[string]interface{}{"new": "b"}},
+		},
+		"new key in higher priority source": {
+			{name: "s2", values: map[string]interface{}{"new": "b"}},
+			{name: "s1", values: map[string]interface{}{"old": "a"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := New()
+			for _, source := range sources {
+				config.AddSource(source)
+			}
+			config.RegisterKey("new", "", "")
+			config.DeprecateKey("old", "new", "")
+
+			err := config.Parse()
+
+			var conflict DeprecatedKeyConflictError
+			assert.True(t, errors.As(err, &conflict))
+			assert.Equal(t, DeprecatedKeyConflictError{Source: "s1", OldKey: "old", NewKey: "new", NewKeySource: "s2"}, conflict)
+			assert.EqualError(t, conflict, "configr: Deprecated key 'old' is set in s1 and its replacement 'new' in s2")
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package configr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Snapshotter is implemented by *Configr and Snapshot, anything Diff() can
+// compare
+type Snapshotter interface {
+	Snapshot() Snapshot
+}
+
+// Snapshot satisfies the Snapshotter interface
+func (s Snapshot) Snapshot() Snapshot {
+	return s
+}
+
+type ChangeKind int
+
+const (
+	KeyAdded ChangeKind = iota
+	KeyRemoved
+	KeyChanged
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case KeyAdded:
+		return "added"
+	case KeyRemoved:
+		return "removed"
+	case KeyChanged:
+		return "changed"
+	}
+
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// Change describes a single key that differs between two configurations, Old
+// is nil for added keys and New for removed keys
+type Change struct {
+	Key  string
+	Kind ChangeKind
+	Old  interface{}
+	New  interface{}
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case KeyAdded:
+		return fmt.Sprintf("+ %s = %#v", c.Key, c.New)
+	case KeyRemoved:
+		return fmt.Sprintf("- %s = %#v", c.Key, c.Old)
+	}
+
+	return fmt.Sprintf("~ %s: %#v -> %#v", c.Key, c.Old, c.New)
+}
+
+// Changes is every difference between two configurations, sorted by key
+type Changes []Change
+
+// String renders one line per change, prefixed with + for added, - for
+// removed and ~ for changed keys
+func (c Changes) String() string {
+	lines := make([]string, len(c))
+	for i, change := range c {
+		lines[i] = change.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Diff compares the values of two configurations key by key, keys are the
+// full delimitered path of each value. Values of keys that are secret in
+// either configuration are redacted.
+func Diff(a, b Snapshotter) Changes {
+	oldTree, newTree := a.Snapshot().tree, b.Snapshot().tree
+	oldValues, newValues := flattenTree(oldTree), flattenTree(newTree)
+
+	keys := make(map[string]interface{}, len(oldValues)+len(newValues))
+	for key := range oldValues {
+		keys[key] = nil
+	}
+	for key := range newValues {
+		keys[key] = nil
+	}
+
+	changes := Changes{}
+	for _, key := range sortedKeys(keys) {
+		oldValue, inOld := oldValues[key]
+		newValue, inNew := newValues[key]
+		// Copied so changes can't reach back into either configuration
+		oldValue, newValue = copyValue(oldValue), copyValue(newValue)
+
+		var change Change
+		switch {
+		case !inOld:
+			change = Change{Key: key, Kind: KeyAdded, New: newValue}
+		case !inNew:
+			change = Change{Key: key, Kind: KeyRemoved, Old: oldValue}
+		case !reflect.DeepEqual(oldValue, newValue):
+			change = Change{Key: key, Kind: KeyChanged, Old: oldValue, New: newValue}
+		default:
+			continue
+		}
+
+		if isSecretIn(oldTree, key) || isSecretIn(newTree, key) {
+			if inOld {
+				change.Old = RedactedValue
+			}
+			if inNew {
+				change.New = RedactedValue
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func flattenTree(t *tree) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+
+	return flattenMap(t.values, t.keyDelimeter)
+}
+
+func isSecretIn(t *tree, key string) bool {
+	return t != nil && t.isSecret(key)
+}
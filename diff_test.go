@@ -0,0 +1,65 @@
+package configr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDiffConfigr(values map[string]interface{}) *Configr {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: values})
+	config.RegisterSecretKey("payment_gateway.private_key", "", nil)
+	config.MustParse()
+
+	return config
+}
+
+func Test_Diff_ItReportsAddedRemovedAndChangedKeys(t *testing.T) {
+	a := newDiffConfigr(map[string]interface{}{
+		"server":          map[string]interface{}{"host": "localhost", "port": 8080},
+		"payment_gateway": map[string]interface{}{"private_key": "hunter1"},
+		"same":            true,
+	})
+	b := newDiffConfigr(map[string]interface{}{
+		"server":          map[string]interface{}{"port": 9090, "tls": true},
+		"payment_gateway": map[string]interface{}{"private_key": "hunter2"},
+		"same":            true,
+	})
+
+	changes := Diff(a, b.Snapshot())
+
+	assert.Equal(t, Changes{
+		{Key: "payment_gateway.private_key", Kind: KeyChanged, Old: RedactedValue, New: RedactedValue},
+		{Key: "server.host", Kind: KeyRemoved, Old: "localhost"},
+		{Key: "server.port", Kind: KeyChanged, Old: 8080, New: 9090},
+		{Key: "server.tls", Kind: KeyAdded, New: true},
+	}, changes)
+	assert.Equal(t, `~ payment_gateway.private_key: "******" -> "******"
+- server.host = "localhost"
+~ server.port: 8080 -> 9090
++ server.tls = true`, changes.String())
+}
+
+func Test_Diff_ItReturnsNoChangesForEqualConfigs(t *testing.T) {
+	a := newDiffConfigr(map[string]interface{}{"t1": 1})
+
+	assert.Equal(t, Changes{}, Diff(a, a))
+	assert.Equal(t, Changes{{Key: "t1", Kind: KeyAdded, New: 1}}, Diff(New(), a))
+}
+
+func Test_Diff_ItReturnsCopiesOfValues(t *testing.T) {
+	a := newDiffConfigr(map[string]interface{}{"hosts": []interface{}{"a", "b"}})
+
+	changes := Diff(New(), a)
+	changes[0].New.([]interface{})[0] = "mutated"
+
+	value, _ := a.Get("hosts")
+	assert.Equal(t, []interface{}{"a", "b"}, value)
+}
+
+func Test_ChangeKind_String(t *testing.T) {
+	assert.Equal(t, "added", KeyAdded.String())
+	assert.Equal(t, "removed", KeyRemoved.String())
+	assert.Equal(t, "changed", KeyChanged.String())
+}
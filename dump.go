@@ -0,0 +1,91 @@
+package configr
+
+import (
+	"errors"
+)
+
+var ErrNotASubtree = errors.New("configr: Key does not hold a subtree of values")
+
+// DumpOptions controls which values Dump() encodes
+type DumpOptions struct {
+	// ExcludeDefaults leaves out values that no source supplied
+	ExcludeDefaults bool
+	// Subtree only dumps the values beneath the given key
+	Subtree string
+	// ShowSecrets disables the redaction of secret keys
+	ShowSecrets bool
+}
+
+// Dump encodes the effective configuration as of the last Parse() using the
+// passed Encoder, e.g. for attaching to a support ticket or logging at boot.
+// Secret values are redacted unless options.ShowSecrets is set.
+func Dump(e Encoder, options DumpOptions) ([]byte, error) {
+	return globalConfigr.Dump(e, options)
+}
+func (c *Configr) Dump(e Encoder, options DumpOptions) ([]byte, error) {
+	return c.Snapshot().Dump(e, options)
+}
+
+// Dump follows the same rules as Configr.Dump()
+func (s Snapshot) Dump(e Encoder, options DumpOptions) ([]byte, error) {
+	values, err := s.tree.dumpValues(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Marshal(values)
+}
+
+func (t *tree) dumpValues(options DumpOptions) (map[string]interface{}, error) {
+	if t == nil || !t.parsed {
+		return nil, ErrParseHasntBeenCalled
+	}
+
+	values := t.values
+	if options.Subtree != "" {
+		subtree, err := t.get(options.Subtree)
+		if err != nil {
+			return nil, err
+		}
+
+		subMap, ok := subtree.(map[string]interface{})
+		if !ok {
+			return nil, ErrNotASubtree
+		}
+		values = subMap
+	}
+
+	dumped := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if dumpedValue, ok := t.dumpValue(t.joinKey(t.normaliseKey(options.Subtree), key), value, options); ok {
+			dumped[key] = dumpedValue
+		}
+	}
+
+	return dumped, nil
+}
+
+// dumpValue returns the value of key as it should be dumped, or false when it
+// should be left out
+func (t *tree) dumpValue(key string, value interface{}, options DumpOptions) (interface{}, bool) {
+	if subMap, ok := value.(map[string]interface{}); ok && len(subMap) > 0 {
+		dumped := make(map[string]interface{}, len(subMap))
+		for subKey, subValue := range subMap {
+			if dumpedValue, ok := t.dumpValue(t.joinKey(key, subKey), subValue, options); ok {
+				dumped[subKey] = dumpedValue
+			}
+		}
+
+		return dumped, len(dumped) > 0
+	}
+
+	if options.ExcludeDefaults && len(t.origins[key]) == 0 {
+		return nil, false
+	}
+
+	if !options.ShowSecrets && t.isSecret(key) {
+		return RedactedValue, true
+	}
+
+	return value, true
+}
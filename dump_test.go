@@ -0,0 +1,73 @@
+package configr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDumpConfigr() *Configr {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"server": map[string]interface{}{"host": "localhost"},
+		"payment_gateway": map[string]interface{}{
+			"private_key": "hunter2",
+			"public_key":  "abc",
+		},
+	}})
+	config.RegisterKey("server.host", "", "")
+	config.RegisterKey("server.port", "", 8080)
+	config.RegisterKey("payment_gateway.public_key", "", "")
+	config.RegisterSecretKey("payment_gateway.private_key", "", "")
+
+	return config
+}
+
+func Test_Dump_ItEncodesTheEffectiveConfigWithSecretsRedacted(t *testing.T) {
+	config := newDumpConfigr()
+	assert.NoError(t, config.Parse())
+	g := &MockGenerator{}
+
+	g.On("Marshal", map[string]interface{}{
+		"server": map[string]interface{}{"host": "localhost", "port": 8080},
+		"payment_gateway": map[string]interface{}{
+			"private_key": RedactedValue,
+			"public_key":  "abc",
+		},
+	}).Return([]byte("dumped"), nil)
+
+	dumped, err := config.Dump(g, DumpOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("dumped"), dumped)
+	g.AssertExpectations(t)
+}
+
+func Test_Dump_ItHonoursOptions(t *testing.T) {
+	config := newDumpConfigr()
+	assert.NoError(t, config.Parse())
+	g := &MockGenerator{}
+
+	g.On("Marshal", map[string]interface{}{"host": "localhost"}).Return([]byte{}, nil)
+	g.On("Marshal", map[string]interface{}{
+		"private_key": "hunter2",
+		"public_key":  "abc",
+	}).Return([]byte{}, nil)
+
+	_, err := config.Dump(g, DumpOptions{Subtree: "server", ExcludeDefaults: true})
+	assert.NoError(t, err)
+	_, err = config.Dump(g, DumpOptions{Subtree: "payment_gateway", ShowSecrets: true})
+	assert.NoError(t, err)
+	g.AssertExpectations(t)
+
+	_, err = config.Dump(g, DumpOptions{Subtree: "server.host"})
+	assert.Equal(t, ErrNotASubtree, err)
+	_, err = config.Dump(g, DumpOptions{Subtree: "missing"})
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
+func Test_Dump_ItErrorsBeforeParse(t *testing.T) {
+	_, err := newDumpConfigr().Dump(&MockGenerator{}, DumpOptions{})
+
+	assert.Equal(t, ErrParseHasntBeenCalled, err)
+}
@@ -0,0 +1,141 @@
+package configr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParseErrors collects every source error, validation error and missing
+// required key found during a single Parse(). It supports errors.Is and
+// errors.As against any of the errors it holds, and renders as a multi-line
+// report grouped by key.
+type ParseErrors []error
+
+// keyedError is implemented by errors relating to a single key, ParseErrors
+// groups them under that key when rendering.
+type keyedError interface {
+	errorKey() string
+	keyMessage() string
+}
+
+// ConversionError is returned by the typed getters when a key's value can't be
+// converted to the requested type, and by Parse() when a source's value can't
+// be converted to its key's declared type (Source names that source).
+type ConversionError struct {
+	Key    string
+	Type   string
+	Source string
+	Err    error
+}
+
+func newConversionError(key, typeName string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return ConversionError{Key: key, Type: typeName, Err: err}
+}
+
+func (e ConversionError) Error() string {
+	if e.Source != "" {
+		return fmt.Sprintf("configr: Unable to convert key '%s' from source '%s' to %s: %v", e.Key, e.Source, e.Type, e.Err)
+	}
+	return fmt.Sprintf("configr: Unable to convert key '%s' to %s: %v", e.Key, e.Type, e.Err)
+}
+
+func (e ConversionError) Unwrap() error {
+	return e.Err
+}
+
+func (e ConversionError) errorKey() string {
+	return e.Key
+}
+
+func (e ConversionError) keyMessage() string {
+	return fmt.Sprintf("unable to convert to %s: %v", e.Type, e.Err) + fromSource(e.Source)
+}
+
+func (v ValidationError) errorKey() string {
+	return v.Key
+}
+
+func (v ValidationError) keyMessage() string {
+	return v.Err.Error() + fromSource(v.Source)
+}
+
+func fromSource(source string) string {
+	if source == "" {
+		return ""
+	}
+
+	return " (from " + source + ")"
+}
+
+func (e ParseErrors) Error() string {
+	var general []string
+	byKey := make(map[string][]string)
+
+	for _, err := range e {
+		switch err := err.(type) {
+		case ErrRequiredKeysMissing:
+			for _, key := range err {
+				byKey[key] = append(byKey[key], "required key is missing")
+			}
+		case keyedError:
+			byKey[err.errorKey()] = append(byKey[err.errorKey()], err.keyMessage())
+		default:
+			general = append(general, err.Error())
+		}
+	}
+
+	noun := "errors"
+	if len(e) == 1 {
+		noun = "error"
+	}
+	lines := []string{fmt.Sprintf("configr: %d %s parsing configuration:", len(e), noun)}
+
+	for _, message := range general {
+		lines = append(lines, "  - "+message)
+	}
+
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		lines = append(lines, "  "+key+":")
+		for _, message := range byKey[key] {
+			lines = append(lines, "    - "+message)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (e ParseErrors) Unwrap() []error {
+	return e
+}
+
+func (e ParseErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e ParseErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,155 @@
+package configr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// Origin describes a source which supplied a value for a key. Location is only
+// set for Sources implementing KeyLocator.
+type Origin struct {
+	Source   string
+	Location string
+	Value    interface{}
+}
+
+func (o Origin) String() string {
+	if o.Location != "" {
+		return fmt.Sprintf("%s (%s) = %#v", o.Source, o.Location, o.Value)
+	}
+
+	return fmt.Sprintf("%s = %#v", o.Source, o.Value)
+}
+
+// Explanation describes where the effective value of a key came from. Origin
+// is the highest priority source that supplied the key and is nil when no
+// source did, Overridden lists every lower priority source that also supplied
+// the key (highest priority first).
+type Explanation struct {
+	Key         string
+	Value       interface{}
+	Origin      *Origin
+	Overridden  []Origin
+	DefaultUsed bool
+}
+
+func (e Explanation) String() string {
+	lines := []string{fmt.Sprintf("%s = %#v", e.Key, e.Value)}
+
+	switch {
+	case e.Origin != nil:
+		lines = append(lines, "  from: "+e.Origin.String())
+	case e.DefaultUsed:
+		lines = append(lines, "  from: registered default")
+	}
+
+	for _, origin := range e.Overridden {
+		lines = append(lines, "  overrides: "+origin.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Explain reports which source supplied the value of a key as of the last
+// Parse(), which lower priority sources it overrode and whether the
+// registered default was used instead. Keys follow the same rules as Get,
+// secret values are redacted.
+func Explain(key string) (Explanation, error) {
+	return globalConfigr.Explain(key)
+}
+func (c *Configr) Explain(key string) (Explanation, error) {
+	t := c.load()
+
+	value, err := t.get(key)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	if t.isCaseInsensitive {
+		key = strings.ToLower(key)
+	}
+
+	explanation := Explanation{Key: key, Value: t.redact(key, copyValue(value))}
+	if origins := t.origins[key]; len(origins) > 0 {
+		redacted := make([]Origin, len(origins))
+		for i, origin := range origins {
+			origin.Value = t.redact(key, copyValue(origin.Value))
+			redacted[i] = origin
+		}
+		explanation.Origin = &redacted[0]
+		explanation.Overridden = redacted[1:]
+	} else {
+		explanation.DefaultUsed = c.isDefaultValue(key, value)
+	}
+
+	return explanation, nil
+}
+
+func (c *Configr) isDefaultValue(key string, value interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	defaults := make(map[string]interface{})
+	for defaultKey, defaultValue := range c.defaultValues {
+		c.mergeMap(defaultKey, defaultValue, defaults)
+	}
+
+	defaultValue, err := c.getFrom(defaults, key)
+	return err == nil && reflect.DeepEqual(defaultValue, value)
+}
+
+func (c *Configr) recordOrigins(origins map[string][]Origin, name string, source Source, sourceValues map[string]interface{}) {
+	locator, _ := innerSource(source).(KeyLocator)
+
+	for key, value := range flattenMap(sourceValues, c.keyDelimeter) {
+		origin := Origin{Source: name, Value: value}
+		if locator != nil {
+			origin.Location = locator.Locate(key, c.keySplitterFn)
+		}
+
+		if c.isCaseInsensitive {
+			key = strings.ToLower(key)
+		}
+		origins[key] = append([]Origin{origin}, origins[key]...)
+	}
+}
+
+func sourceName(source Source, index int) string {
+	source = innerSource(source)
+	if named, ok := source.(Named); ok {
+		return named.Name()
+	}
+
+	return fmt.Sprintf("source #%d (%T)", index+1, source)
+}
+
+// flattenMap converts a nested map into a single level map keyed by the full
+// delimitered path of each leaf value:
+//    In: {"t1": {"t11": 1}}
+//    Out: {"t1.t11": 1}
+func flattenMap(values map[string]interface{}, delimeter string) map[string]interface{} {
+	flattened := make(map[string]interface{})
+	flattenInto(flattened, "", values, delimeter)
+
+	return flattened
+}
+
+func flattenInto(flattened map[string]interface{}, prefix string, values map[string]interface{}, delimeter string) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + delimeter + key
+		}
+
+		if value != nil && reflect.TypeOf(value).Kind() == reflect.Map {
+			if subMap := cast.ToStringMap(value); len(subMap) > 0 {
+				flattenInto(flattened, key, subMap, delimeter)
+				continue
+			}
+		}
+
+		flattened[key] = value
+	}
+}
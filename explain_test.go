@@ -0,0 +1,104 @@
+package configr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type namedSource struct {
+	name   string
+	values map[string]interface{}
+}
+
+func (n namedSource) Name() string {
+	return n.name
+}
+
+func (n namedSource) Unmarshal(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+	return n.values, nil
+}
+
+func (n namedSource) Locate(key string, keySplitter KeySplitter) string {
+	return n.name + ":" + key
+}
+
+func Test_Explain_ItReportsTheWinningAndOverriddenSources(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"t1.t11": 1}})
+	config.AddSource(namedSource{name: "s2", values: map[string]interface{}{
+		"t1": map[string]interface{}{"t11": 2},
+	}})
+	config.RegisterKey("t1.t11", "", 3)
+	assert.NoError(t, config.Parse())
+
+	explanation, err := config.Explain("t1.t11")
+	assert.NoError(t, err)
+
+	assert.Equal(t, Explanation{
+		Key:   "t1.t11",
+		Value: 1,
+		Origin: &Origin{
+			Source:   "s1",
+			Location: "s1:t1.t11",
+			Value:    1,
+		},
+		Overridden: []Origin{
+			{Source: "s2", Location: "s2:t1.t11", Value: 2},
+		},
+	}, explanation)
+	assert.Equal(t, "t1.t11 = 1\n  from: s1 (s1:t1.t11) = 1\n  overrides: s2 (s2:t1.t11) = 2", explanation.String())
+}
+
+func Test_Explain_ItReportsWhenTheDefaultWasUsed(t *testing.T) {
+	config := New()
+	config.RegisterKey("t1", "", map[string]interface{}{"t11": "default"})
+	assert.NoError(t, config.Parse())
+
+	explanation, err := config.Explain("t1.t11")
+	assert.NoError(t, err)
+
+	assert.True(t, explanation.DefaultUsed)
+	assert.Nil(t, explanation.Origin)
+	assert.Equal(t, "t1.t11 = \"default\"\n  from: registered default", explanation.String())
+}
+
+func Test_Explain_ItNamesUnnamedSourcesByPosition(t *testing.T) {
+	config := New()
+	config.AddSource(SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		return map[string]interface{}{"t1": 1}, nil
+	}))
+	assert.NoError(t, config.Parse())
+
+	explanation, err := config.Explain("t1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "source #1 (configr.SourceAdapter)", explanation.Origin.Source)
+}
+
+func Test_Explain_ItReturnsGetErrors(t *testing.T) {
+	config := New()
+
+	_, err := config.Explain("t1")
+	assert.Equal(t, ErrParseHasntBeenCalled, err)
+
+	assert.NoError(t, config.Parse())
+	_, err = config.Explain("t1")
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
+func Test_flattenMap_ItFlattensNestedMapsToDelimiteredKeys(t *testing.T) {
+	values := map[string]interface{}{
+		"t1": 1,
+		"t2": map[string]interface{}{
+			"t21": map[interface{}]interface{}{"t211": true},
+			"t22": map[string]interface{}{},
+		},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"t1":          1,
+		"t2.t21.t211": true,
+		"t2.t22":      map[string]interface{}{},
+	}, flattenMap(values, "."))
+}
@@ -3,8 +3,10 @@ package configr
 import (
 	"errors"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type FileDecoder interface {
@@ -43,8 +45,11 @@ func registerExtensionsToMap(name string, fileExtensions []string, extMap map[st
 }
 
 type File struct {
+	name         string
 	filePath     string
 	encodingName string
+	modTime      time.Time
+	size         int64
 }
 
 func NewFile(path string) *File {
@@ -71,17 +76,37 @@ func (f *File) Path() string {
 	return f.filePath
 }
 
+// SetName overrides the name the File is reported under in explanations,
+// warnings and errors, which defaults to its path
+func (f *File) SetName(name string) {
+	f.name = name
+}
+
+// Name satisfies the Named interface, a File is named by its path unless
+// given a name with SetName()
+func (f *File) Name() string {
+	if f.name != "" {
+		return f.name
+	}
+
+	return f.filePath
+}
+
 func (f *File) Unmarshal(_ []string, _ KeySplitter) (map[string]interface{}, error) {
 	if decoder, found := RegisteredFileDecoders[f.encodingName]; found {
 		values := make(map[string]interface{})
 
+		if info, err := os.Stat(f.filePath); err == nil {
+			f.modTime, f.size = info.ModTime(), info.Size()
+		}
+
 		fileBytes, err := ioutil.ReadFile(f.filePath)
 		if err != nil {
 			return values, err
 		}
 
 		if err := decoder.Unmarshal(fileBytes, &values); err != nil {
-			return values, err
+			return values, newDecodeError(f.Name(), fileBytes, err)
 		}
 
 		return values, nil
@@ -90,6 +115,17 @@ func (f *File) Unmarshal(_ []string, _ KeySplitter) (map[string]interface{}, err
 	return map[string]interface{}{}, ErrUnknownEncoding
 }
 
+// Changed satisfies the Watchable interface, it reports true when the file
+// has been modified since it was last unmarshalled.
+func (f *File) Changed() (bool, error) {
+	info, err := os.Stat(f.filePath)
+	if err != nil {
+		return false, err
+	}
+
+	return !info.ModTime().Equal(f.modTime) || info.Size() != f.size, nil
+}
+
 func (f *File) Marshal(v interface{}) ([]byte, error) {
 	if encoder, found := RegisteredFileEncoders[f.encodingName]; found {
 		return encoder.Marshal(v)
@@ -1,6 +1,9 @@
 package configr
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -114,3 +117,26 @@ func (m MockFileSourcer) Unmarshal(b []byte, v interface{}) error {
 	args := m.Called(b, v)
 	return args.Error(0)
 }
+
+func Test_ItReportsChangedOnceFileIsModified(t *testing.T) {
+	defer resetGlobals()()
+	RegisterFileDecoder("json", FileDecoderAdapter(json.Unmarshal))
+
+	filePath := "/tmp/configr_changed.json"
+	assert.NoError(t, ioutil.WriteFile(filePath, []byte(`{"t1": 1}`), os.ModePerm))
+	defer os.Remove(filePath)
+
+	f := NewFile(filePath)
+	_, err := f.Unmarshal([]string{}, nil)
+	assert.NoError(t, err)
+
+	changed, err := f.Changed()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	assert.NoError(t, ioutil.WriteFile(filePath, []byte(`{"t1": 12}`), os.ModePerm))
+
+	changed, err = f.Changed()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+}
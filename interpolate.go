@@ -0,0 +1,256 @@
+package configr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+const envReferencePrefix = "env:"
+
+var (
+	ErrUnresolvedReference   = errors.New("configr: Unresolved reference")
+	ErrUnterminatedReference = errors.New("configr: Unterminated reference")
+	ErrReferenceCycle        = errors.New("configr: Reference cycle")
+)
+
+// InterpolationError is returned when a reference in the value of Key can't
+// be resolved. Cycle holds the keys involved when Err is ErrReferenceCycle.
+type InterpolationError struct {
+	Key       string
+	Reference string
+	Cycle     []string
+	Err       error
+}
+
+func (e InterpolationError) Error() string {
+	return fmt.Sprintf("configr: Unable to interpolate '%s' in key '%s': %s", e.reference(), e.Key, e.reason())
+}
+
+func (e InterpolationError) Unwrap() error {
+	return e.Err
+}
+
+func (e InterpolationError) errorKey() string {
+	return e.Key
+}
+
+func (e InterpolationError) keyMessage() string {
+	return fmt.Sprintf("unable to interpolate '%s': %s", e.reference(), e.reason())
+}
+
+func (e InterpolationError) reference() string {
+	if e.Err == ErrUnterminatedReference {
+		return e.Reference
+	}
+
+	return "${" + e.Reference + "}"
+}
+
+func (e InterpolationError) reason() string {
+	switch e.Err {
+	case ErrUnresolvedReference:
+		return "no such key or environment variable"
+	case ErrUnterminatedReference:
+		return "missing closing '}'"
+	case ErrReferenceCycle:
+		return "reference cycle " + strings.Join(e.Cycle, " -> ")
+	}
+
+	return e.Err.Error()
+}
+
+// SetInterpolation enables interpolation of string values once every source
+// has been merged. "${server.host}" is replaced with the value of another key
+// and "${env:HOME}" with an environment variable, "$${" escapes a literal
+// "${". A value made up of a single reference takes on the referenced value's
+// type. Validators see the resolved values.
+func SetInterpolation(enabled bool) {
+	globalConfigr.SetInterpolation(enabled)
+}
+func (c *Configr) SetInterpolation(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.isInterpolating = enabled
+}
+
+func hasReferences(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && strings.Contains(s, "${")
+}
+
+// interpolateValues resolves references in every string value of state,
+// converting and validating each value once resolved. Keys referencing
+// secret keys are recorded as secret themselves.
+func (c *Configr) interpolateValues(state *parseState) {
+	state.interpolatedSecrets = make(map[string]struct{})
+	state.uninterpolated = make(map[string]interface{})
+	in := &interpolation{
+		c:        c,
+		values:   state.values,
+		resolved: make(map[string]interface{}),
+		secrets: &tree{
+			values:              state.values,
+			keyDelimeter:        c.keyDelimeter,
+			isCaseInsensitive:   c.isCaseInsensitive,
+			secretKeys:          c.secretKeys,
+			interpolatedSecrets: state.interpolatedSecrets,
+		},
+	}
+
+	flattened := flattenMap(state.values, c.keyDelimeter)
+	resolved := make(map[string]interface{})
+	for _, key := range sortedKeys(flattened) {
+		if !hasReferences(flattened[key]) {
+			continue
+		}
+
+		value, err := in.resolveKey(key)
+		if err != nil {
+			// Errors from referenced keys are reported against those keys
+			if interpolationErr, ok := err.(InterpolationError); !ok || interpolationErr.Key == key {
+				state.errs = append(state.errs, err)
+			}
+			continue
+		}
+		resolved[key] = value
+		state.uninterpolated[key] = flattened[key]
+	}
+
+	for _, key := range sortedKeys(resolved) {
+		value, errs := c.coerceAndValidate(key, resolved[key])
+		c.mergeMap(key, value, state.values)
+		if in.secrets.isSecret(key) {
+			errs = in.redactErrors(errs)
+		}
+		if origins := state.origins[key]; len(origins) > 0 {
+			errs = attributeErrors(origins[0].Source, errs)
+		}
+		state.errs = append(state.errs, errs...)
+	}
+}
+
+type interpolation struct {
+	c         *Configr
+	values    map[string]interface{}
+	resolved  map[string]interface{}
+	resolving []string
+	secrets   *tree
+}
+
+func (in *interpolation) resolveKey(key string) (interface{}, error) {
+	if in.c.isCaseInsensitive {
+		key = strings.ToLower(key)
+	}
+	if value, found := in.resolved[key]; found {
+		return value, nil
+	}
+
+	for i, resolvingKey := range in.resolving {
+		if resolvingKey == key {
+			cycle := append(append([]string{}, in.resolving[i:]...), key)
+			return nil, InterpolationError{Key: key, Reference: cycle[1], Cycle: cycle, Err: ErrReferenceCycle}
+		}
+	}
+
+	value, err := in.c.getFrom(in.values, key)
+	if err != nil {
+		return nil, err
+	}
+	if !hasReferences(value) {
+		return value, nil
+	}
+
+	in.resolving = append(in.resolving, key)
+	value, err = in.expand(key, value.(string))
+	in.resolving = in.resolving[:len(in.resolving)-1]
+	if err != nil {
+		return nil, err
+	}
+
+	in.resolved[key] = value
+	return value, nil
+}
+
+// expand replaces every reference in s, the value of key
+func (in *interpolation) expand(key, s string) (interface{}, error) {
+	if strings.HasPrefix(s, "${") && strings.Index(s, "}") == len(s)-1 {
+		return in.resolveReference(key, s[2:len(s)-1])
+	}
+
+	var expanded strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			expanded.WriteString(s)
+			break
+		}
+
+		if start > 0 && s[start-1] == '$' {
+			expanded.WriteString(s[:start-1] + "${")
+			s = s[start+2:]
+			continue
+		}
+
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			return nil, InterpolationError{Key: key, Reference: s[start:], Err: ErrUnterminatedReference}
+		}
+		reference := s[start+2 : start+end]
+
+		value, err := in.resolveReference(key, reference)
+		if err != nil {
+			return nil, err
+		}
+		str, err := cast.ToStringE(value)
+		if err != nil {
+			return nil, InterpolationError{Key: key, Reference: reference, Err: err}
+		}
+
+		expanded.WriteString(s[:start] + str)
+		s = s[start+end+1:]
+	}
+
+	return expanded.String(), nil
+}
+
+func (in *interpolation) resolveReference(key, reference string) (interface{}, error) {
+	if strings.HasPrefix(reference, envReferencePrefix) {
+		if value, found := os.LookupEnv(strings.TrimPrefix(reference, envReferencePrefix)); found {
+			return value, nil
+		}
+		return nil, InterpolationError{Key: key, Reference: reference, Err: ErrUnresolvedReference}
+	}
+
+	value, err := in.resolveKey(reference)
+	if err == ErrKeyNotFound {
+		return nil, InterpolationError{Key: key, Reference: reference, Err: ErrUnresolvedReference}
+	}
+	if err == nil && in.secrets.isSecret(in.secrets.normaliseKey(reference)) {
+		in.secrets.interpolatedSecrets[in.secrets.normaliseKey(key)] = struct{}{}
+	}
+
+	return value, err
+}
+
+// redactErrors masks every secret value in the errors of a key interpolated
+// from secrets, the errors were redacted before the key was known to be
+// secret
+func (in *interpolation) redactErrors(errs []error) []error {
+	for i, err := range errs {
+		switch err := err.(type) {
+		case ValidationError:
+			err.Err = in.secrets.redactError("", in.values, err.Err)
+			errs[i] = err
+		case ConversionError:
+			err.Err = in.secrets.redactError("", in.values, err.Err)
+			errs[i] = err
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,119 @@
+package configr
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse_ItLeavesReferencesAloneByDefault(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"t1": "${t2}", "t2": "a"}})
+
+	assert.NoError(t, config.Parse())
+
+	value, _ := config.String("t1")
+	assert.Equal(t, "${t2}", value)
+}
+
+func Test_Parse_ItInterpolatesKeysAndEnvVars(t *testing.T) {
+	os.Setenv("CONFIGR_TEST_HOME", "/home/configr")
+	defer os.Unsetenv("CONFIGR_TEST_HOME")
+
+	config := New()
+	config.SetInterpolation(true)
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"server": map[string]interface{}{
+			"host": "localhost",
+			"port": 8080,
+		},
+		"address": "${server.host}:${server.port}",
+		"data":    "${env:CONFIGR_TEST_HOME}/data",
+		"literal": "$${server.host}",
+		"url":     "http://${address}",
+		"alias":   "${server.port}",
+	}})
+	config.RegisterKey("quoted", "", "${server.port}")
+
+	assert.NoError(t, config.Parse())
+
+	for key, expected := range map[string]interface{}{
+		"address": "localhost:8080",
+		"data":    "/home/configr/data",
+		"literal": "${server.host}",
+		"url":     "http://localhost:8080",
+		"alias":   8080,
+		"quoted":  "8080",
+	} {
+		value, err := config.Get(key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, key)
+	}
+}
+
+func Test_Parse_ItValidatesInterpolatedValues(t *testing.T) {
+	validatedValues := []interface{}{}
+
+	config := New()
+	config.SetInterpolation(true)
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"t1": "${t2}-b", "t2": "a"}})
+	config.RegisterKey("t1", "", nil, func(value interface{}) error {
+		validatedValues = append(validatedValues, value)
+		return errors.New("nope")
+	})
+
+	err := config.Parse()
+
+	assert.Equal(t, ParseErrors{ValidationError{Key: "t1", Source: "s1", Err: errors.New("nope")}}, err)
+	assert.Equal(t, []interface{}{"a-b"}, validatedValues)
+}
+
+func Test_Parse_ItReportsUnresolvedReferencesAndCycles(t *testing.T) {
+	config := New()
+	config.SetInterpolation(true)
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"a":       "${b}",
+		"b":       "x${a}",
+		"c":       "${missing}",
+		"d":       "${c}",
+		"e":       "${env:CONFIGR_TEST_UNSET}",
+		"f":       "${never",
+		"nothing": "plain",
+	}})
+
+	err := config.Parse()
+
+	assert.Equal(t, ParseErrors{
+		InterpolationError{Key: "a", Reference: "b", Cycle: []string{"a", "b", "a"}, Err: ErrReferenceCycle},
+		InterpolationError{Key: "b", Reference: "a", Cycle: []string{"b", "a", "b"}, Err: ErrReferenceCycle},
+		InterpolationError{Key: "c", Reference: "missing", Err: ErrUnresolvedReference},
+		InterpolationError{Key: "e", Reference: "env:CONFIGR_TEST_UNSET", Err: ErrUnresolvedReference},
+		InterpolationError{Key: "f", Reference: "${never", Err: ErrUnterminatedReference},
+	}, err)
+	assert.True(t, errors.Is(err, ErrReferenceCycle))
+
+	errs := err.(ParseErrors)
+	assert.EqualError(t, errs[0], "configr: Unable to interpolate '${b}' in key 'a': reference cycle a -> b -> a")
+	assert.EqualError(t, errs[2], "configr: Unable to interpolate '${missing}' in key 'c': no such key or environment variable")
+	assert.EqualError(t, errs[4], "configr: Unable to interpolate '${never' in key 'f': missing closing '}'")
+}
+
+func Test_Parse_ItInterpolatesTheOriginalValuesWhenParsedAgain(t *testing.T) {
+	config := New()
+	config.SetInterpolation(true)
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"host": "localhost"}})
+	config.RegisterKey("literal", "", "$${literal}")
+	config.RegisterKey("url", "", "http://${host}")
+	config.RegisterKey("host", "", nil)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, config.Parse())
+
+		literal, _ := config.String("literal")
+		assert.Equal(t, "${literal}", literal)
+		url, _ := config.String("url")
+		assert.Equal(t, "http://localhost", url)
+	}
+}
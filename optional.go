@@ -0,0 +1,97 @@
+package configr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrSourceUnavailable can be returned (or wrapped) by Sources which can't
+// reach their underlying data, e.g. a database that is down, allowing
+// Optional() to skip them
+var ErrSourceUnavailable = errors.New("configr: Source unavailable")
+
+// Optional wraps a source so that Parse() skips it with a Warning, rather
+// than failing, when its data doesn't exist (e.g. a missing file), it returns
+// ErrSourceUnavailable or it times out (see Timeout()). Any other error, such
+// as malformed content, still fails Parse().
+func Optional(source Source) Source {
+	return &optionalSource{Source: source}
+}
+
+type optionalSource struct {
+	Source
+	available bool
+	calls     sourceCalls
+}
+
+func (o *optionalSource) Unmarshal(keys []string, keySplitter KeySplitter) (map[string]interface{}, error) {
+	return o.UnmarshalContext(context.Background(), keys, keySplitter)
+}
+
+func (o *optionalSource) UnmarshalContext(ctx context.Context, keys []string, keySplitter KeySplitter) (map[string]interface{}, error) {
+	values, err := unmarshalSource(ctx, o.Source, &o.calls, keys, keySplitter)
+	o.available = !isUnavailable(err)
+	if !o.available {
+		return map[string]interface{}{}, skippedSourceError{err}
+	}
+
+	return values, err
+}
+
+// Changed satisfies the Watchable interface, a missing source has changed
+// only if it was available when last unmarshalled
+func (o *optionalSource) Changed() (bool, error) {
+	watchable, ok := o.Source.(Watchable)
+	if !ok || o.calls.busy() {
+		return false, nil
+	}
+
+	changed, err := watchable.Changed()
+	if isUnavailable(err) {
+		return o.available, nil
+	}
+
+	return changed, err
+}
+
+func (o *optionalSource) unwrap() Source {
+	return o.Source
+}
+
+func isUnavailable(err error) bool {
+	return err != nil && (errors.Is(err, os.ErrNotExist) || errors.Is(err, ErrSourceUnavailable) || errors.Is(err, context.DeadlineExceeded))
+}
+
+// skippedSourceError is returned by optional sources that are unavailable,
+// populateValues reports it as a Warning
+type skippedSourceError struct {
+	err error
+}
+
+func (e skippedSourceError) Error() string {
+	return fmt.Sprintf("optional source skipped: %v", e.err)
+}
+
+func (e skippedSourceError) Unwrap() error {
+	return e.err
+}
+
+// wrappedSource is implemented by sources wrapping another, such as those
+// returned by Optional()
+type wrappedSource interface {
+	unwrap() Source
+}
+
+// innerSource returns the source at the centre of any wrappers, which is the
+// one that describes itself through Named or KeyLocator
+func innerSource(source Source) Source {
+	for {
+		wrapped, ok := source.(wrappedSource)
+		if !ok {
+			return source
+		}
+		source = wrapped.unwrap()
+	}
+}
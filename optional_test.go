@@ -0,0 +1,92 @@
+package configr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Optional_ItSkipsMissingFilesWithAWarning(t *testing.T) {
+	defer resetGlobals()()
+	RegisterFileDecoder("json", FileDecoderAdapter(json.Unmarshal), "json")
+
+	missingPath := filepath.Join(os.TempDir(), "configr-missing", "config.json")
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"t1": 1}})
+	config.AddSource(Optional(NewFile(missingPath)))
+
+	assert.NoError(t, config.Parse())
+
+	value, _ := config.Int("t1")
+	assert.Equal(t, 1, value)
+	warnings := config.Warnings()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, missingPath, warnings[0].Source)
+	assert.Contains(t, warnings[0].Message, "optional source skipped: open "+missingPath)
+}
+
+func Test_Optional_ItStillFailsOnMalformedContent(t *testing.T) {
+	defer resetGlobals()()
+	RegisterFileDecoder("json", FileDecoderAdapter(json.Unmarshal), "json")
+
+	file, err := ioutil.TempFile("", "configr*.json")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+	file.WriteString("{")
+	file.Close()
+
+	config := New()
+	config.AddSource(Optional(NewFile(file.Name())))
+
+	assert.Error(t, config.Parse())
+	assert.Empty(t, config.Warnings())
+}
+
+func Test_Optional_ItSkipsUnavailableSources(t *testing.T) {
+	config := New()
+	config.AddSource(Optional(SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		return nil, fmt.Errorf("dialing kv daemon: %w", ErrSourceUnavailable)
+	})))
+	config.AddSource(SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		return nil, errors.New("boom")
+	}))
+
+	err := config.Parse()
+
+	assert.Equal(t, ParseErrors{SourceError{Source: "source #2 (configr.SourceAdapter)", Err: errors.New("boom")}}, err)
+}
+
+func Test_Optional_ItIsChangedWhenASourceAppearsOrDisappears(t *testing.T) {
+	defer resetGlobals()()
+	RegisterFileDecoder("json", FileDecoderAdapter(json.Unmarshal), "json")
+
+	dir, err := ioutil.TempDir("", "configr")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+
+	source := Optional(NewFile(path))
+	config := New()
+	config.AddSource(source)
+	assert.NoError(t, config.Parse())
+
+	changed, err := source.(Watchable).Changed()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"t1": 1}`), 0644))
+	changed, _ = source.(Watchable).Changed()
+	assert.True(t, changed)
+
+	assert.NoError(t, config.Reload())
+	assert.NoError(t, os.Remove(path))
+	changed, err = source.(Watchable).Changed()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+}
@@ -0,0 +1,155 @@
+package configr
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// ProfileEnvVar names the environment variable used to pick the active
+// profile when SetProfile() hasn't been called
+var ProfileEnvVar = "CONFIGR_PROFILE"
+
+// SetProfile activates a profile, e.g. "production". During Parse() the
+// values a source provides under the profile's key (e.g.
+// "production.server.port") overlay that source's base values
+// ("server.port"), so keys are always looked up without the profile prefix.
+// Without a call to SetProfile the profile is read from ProfileEnvVar.
+func SetProfile(name string) {
+	globalConfigr.SetProfile(name)
+}
+func (c *Configr) SetProfile(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.isCaseInsensitive {
+		name = strings.ToLower(name)
+	}
+	c.profile = name
+	c.registerProfiles(name)
+}
+
+// RegisterProfiles declares every profile a configuration may hold, the
+// subtrees of inactive profiles are ignored during Parse() and
+// GenerateProfileBlank() emits a skeleton for each
+func RegisterProfiles(names ...string) {
+	globalConfigr.RegisterProfiles(names...)
+}
+func (c *Configr) RegisterProfiles(names ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.registerProfiles(names...)
+}
+
+func (c *Configr) registerProfiles(names ...string) {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if c.isCaseInsensitive {
+			name = strings.ToLower(name)
+		}
+		c.profiles[name] = struct{}{}
+	}
+}
+
+// Profile returns the active profile, or "" when there isn't one
+func Profile() string {
+	return globalConfigr.Profile()
+}
+func (c *Configr) Profile() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.activeProfile()
+}
+
+func (c *Configr) activeProfile() string {
+	profile := c.profile
+	if profile == "" {
+		profile = os.Getenv(ProfileEnvVar)
+	}
+
+	if c.isCaseInsensitive {
+		return strings.ToLower(profile)
+	}
+
+	return profile
+}
+
+// applyProfile moves a source's values under the active profile onto the base
+// keys and drops the values of every other registered profile, returning the
+// values untouched when there are none
+func (c *Configr) applyProfile(profile string, sourceValues map[string]interface{}) map[string]interface{} {
+	if profile == "" && len(c.profiles) == 0 {
+		return sourceValues
+	}
+
+	base := make(map[string]interface{})
+	overlay := make(map[string]interface{})
+	changed := false
+
+	for key, value := range flattenMap(sourceValues, c.keyDelimeter) {
+		path := strings.SplitN(key, c.keyDelimeter, 2)
+		name := path[0]
+		if c.isCaseInsensitive {
+			name = strings.ToLower(name)
+		}
+
+		_, isProfile := c.profiles[name]
+		if !isProfile && name != profile {
+			base[key] = value
+			continue
+		}
+
+		changed = true
+		if name == profile && len(path) == 2 {
+			overlay[path[1]] = value
+		}
+	}
+
+	if !changed {
+		return sourceValues
+	}
+
+	values := make(map[string]interface{})
+	for _, key := range sortedKeys(base) {
+		c.mergeMap(key, base[key], values)
+	}
+	for _, key := range sortedKeys(overlay) {
+		c.mergeMap(key, overlay[key], values)
+	}
+
+	return values
+}
+
+// GenerateProfileBlank generates a blank configuration like GenerateBlank()
+// along with a skeleton of every key beneath each profile (all registered
+// profiles when none are passed)
+func GenerateProfileBlank(e Encoder, profiles ...string) ([]byte, error) {
+	return globalConfigr.GenerateProfileBlank(e, profiles...)
+}
+func (c *Configr) GenerateProfileBlank(e Encoder, profiles ...string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	blankMap, err := c.blankMap()
+	if err != nil {
+		return []byte{}, err
+	}
+
+	if len(profiles) == 0 {
+		for profile := range c.profiles {
+			profiles = append(profiles, profile)
+		}
+		sort.Strings(profiles)
+	}
+
+	for _, profile := range profiles {
+		profileMap, _ := c.blankMap()
+		blankMap[profile] = profileMap
+	}
+
+	return e.Marshal(blankMap)
+}
@@ -0,0 +1,100 @@
+package configr
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SetProfile_ItOverlaysProfileValuesPerSource(t *testing.T) {
+	config := New()
+	config.SetStrict(true)
+	config.RegisterProfiles("staging")
+	config.SetProfile("production")
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"server": map[string]interface{}{"host": "override.com"},
+	}})
+	config.AddSource(namedSource{name: "s2", values: map[string]interface{}{
+		"server":                 map[string]interface{}{"host": "localhost", "port": 8080},
+		"production":             map[string]interface{}{"server": map[string]interface{}{"host": "prod.com", "port": 443}},
+		"staging.server.port":    8443,
+		"production.server.tls":  true,
+		"payment_gateway.public": "abc",
+	}})
+	config.RegisterKey("server.host", "", "")
+	config.RegisterKey("server.port", "", 80)
+	config.RegisterKey("server.tls", "", false)
+	config.RegisterKey("payment_gateway.public", "", "")
+
+	assert.NoError(t, config.Parse())
+
+	for key, expected := range map[string]interface{}{
+		"server.host":            "override.com",
+		"server.port":            443,
+		"server.tls":             true,
+		"payment_gateway.public": "abc",
+	} {
+		value, err := config.Get(key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, key)
+	}
+
+	_, err := config.Get("staging")
+	assert.Equal(t, ErrKeyNotFound, err)
+	assert.Equal(t, "production", config.Profile())
+}
+
+func Test_SetProfile_ItAsksSourcesForProfileKeys(t *testing.T) {
+	var expectedKeys []string
+
+	config := New()
+	config.SetProfile("production")
+	config.AddSource(SourceAdapter(func(keys []string, _ KeySplitter) (map[string]interface{}, error) {
+		expectedKeys = keys
+		return map[string]interface{}{"production.server.port": 443}, nil
+	}))
+	config.RegisterKey("server.port", "", 80)
+
+	assert.NoError(t, config.Parse())
+
+	assert.Equal(t, []string{"production.server.port", "server.port"}, expectedKeys)
+	port, _ := config.Int("server.port")
+	assert.Equal(t, 443, port)
+}
+
+func Test_Profile_ItIsReadFromTheEnvironment(t *testing.T) {
+	os.Setenv(ProfileEnvVar, "staging")
+	defer os.Unsetenv(ProfileEnvVar)
+
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"t1":      1,
+		"staging": map[string]interface{}{"t1": 2},
+	}})
+	config.RegisterKey("t1", "", nil)
+
+	assert.NoError(t, config.Parse())
+
+	value, _ := config.Int("t1")
+	assert.Equal(t, 2, value)
+	assert.Equal(t, "staging", config.Profile())
+}
+
+func Test_GenerateProfileBlank_ItEmitsASkeletonPerProfile(t *testing.T) {
+	config := New()
+	g := &MockGenerator{}
+	config.RegisterProfiles("staging", "production")
+	config.RegisterKey("server.port", "", 80)
+
+	g.On("Marshal", map[string]interface{}{
+		"server":     map[string]interface{}{"port": 80},
+		"production": map[string]interface{}{"server": map[string]interface{}{"port": 80}},
+		"staging":    map[string]interface{}{"server": map[string]interface{}{"port": 80}},
+	}).Return([]byte{}, nil)
+
+	_, err := config.GenerateProfileBlank(g)
+
+	assert.NoError(t, err)
+	g.AssertExpectations(t)
+}
@@ -0,0 +1,176 @@
+package configr
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema generates a JSON Schema (draft 2020-12) describing the registered
+// keys, nested keys become nested objects. Types come from struct fields
+// registered with RegisterFromStruct() or are inferred from defaults, the
+// defaults of secret keys are left out.
+func JSONSchema() ([]byte, error) {
+	return globalConfigr.JSONSchema()
+}
+func (c *Configr) JSONSchema() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.registeredKeys) == 0 {
+		return []byte{}, ErrNoRegisteredValues
+	}
+
+	root := objectSchema()
+	root["$schema"] = JSONSchemaDialect
+
+	for _, key := range c.keyOrder {
+		c.addKeyToSchema(root, key)
+	}
+
+	return json.MarshalIndent(root, "", "	")
+}
+
+func (c *Configr) addKeyToSchema(root map[string]interface{}, key string) {
+	_, isRequired := c.requiredKeys[key]
+	path := c.keySplitterFn(key)
+
+	parent := root
+	for _, name := range path[:len(path)-1] {
+		if isRequired {
+			addRequired(parent, name)
+		}
+
+		properties := parent["properties"].(map[string]interface{})
+		child, ok := properties[name].(map[string]interface{})
+		if !ok || child["type"] != "object" {
+			child = objectSchema()
+			properties[name] = child
+		}
+		if _, ok := child["properties"]; !ok {
+			child["properties"] = make(map[string]interface{})
+		}
+		parent = child
+	}
+
+	name := path[len(path)-1]
+	if isRequired {
+		addRequired(parent, name)
+	}
+
+	properties := parent["properties"].(map[string]interface{})
+	if existing, ok := properties[name].(map[string]interface{}); ok && existing["type"] == "object" {
+		// Already holds keys registered beneath this one
+		if description := c.registeredKeys[key]; description != "" {
+			existing["description"] = description
+		}
+		return
+	}
+
+	schema := typeSchema(c.keyType(key))
+	if description := c.registeredKeys[key]; description != "" {
+		schema["description"] = description
+	}
+	if example, found := c.examples[key]; found {
+		schema["examples"] = []interface{}{example}
+	}
+	if defaultValue, found := c.defaultValues[key]; found {
+		if c.load().isSecret(key) {
+			schema["writeOnly"] = true
+		} else {
+			schema["default"] = schemaValue(defaultValue)
+		}
+	}
+
+	properties[name] = schema
+}
+
+// keyType returns the type a key was registered with, from a struct field or
+// its default, or nil when unknown
+func (c *Configr) keyType(key string) reflect.Type {
+	if keyType, found := c.keyTypes[key]; found {
+		return keyType
+	}
+
+	if defaultValue, found := c.defaultValues[key]; found {
+		return reflect.TypeOf(defaultValue)
+	}
+
+	return nil
+}
+
+func objectSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": make(map[string]interface{}),
+	}
+}
+
+func addRequired(schema map[string]interface{}, name string) {
+	required, _ := schema["required"].([]string)
+	for _, existing := range required {
+		if existing == name {
+			return
+		}
+	}
+
+	schema["required"] = append(required, name)
+}
+
+func typeSchema(t reflect.Type) map[string]interface{} {
+	schema := make(map[string]interface{})
+	if t == nil {
+		return schema
+	}
+
+	switch t {
+	case durationType:
+		schema["type"] = "string"
+		schema["pattern"] = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+		return schema
+	case timeType:
+		schema["type"] = "string"
+		schema["format"] = "date-time"
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		schema["type"] = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		schema["type"] = "integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema["type"] = "integer"
+		schema["minimum"] = 0
+	case reflect.Float32, reflect.Float64:
+		schema["type"] = "number"
+	case reflect.String:
+		schema["type"] = "string"
+	case reflect.Slice, reflect.Array:
+		schema["type"] = "array"
+		if items := typeSchema(t.Elem()); len(items) > 0 {
+			schema["items"] = items
+		}
+	case reflect.Map:
+		schema["type"] = "object"
+		if values := typeSchema(t.Elem()); len(values) > 0 {
+			schema["additionalProperties"] = values
+		}
+	case reflect.Ptr:
+		return typeSchema(t.Elem())
+	}
+
+	return schema
+}
+
+// schemaValue converts a default into the value it would take in a config
+// file
+func schemaValue(value interface{}) interface{} {
+	if duration, ok := value.(time.Duration); ok {
+		return duration.String()
+	}
+
+	return value
+}
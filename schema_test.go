@@ -0,0 +1,68 @@
+package configr
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_JSONSchema_ItDescribesTheRegisteredKeys(t *testing.T) {
+	config := New()
+	config.RegisterKey("server.port", "Port to listen on", 8080)
+	config.RegisterKey("server.timeout", "", 30*time.Second)
+	config.RegisterKey("tags", "", []string{"a"})
+	config.RegisterSecretKey("payment_gateway.private_key", "", "dev-key")
+	assert.NoError(t, config.RegisterFromStruct(&struct {
+		Email struct {
+			Subject string `configr:"subject,required" desc:"Subject line" example:"Hello"`
+			Retries uint   `configr:"retries"`
+		} `configr:"email"`
+	}{}))
+
+	schema, err := config.JSONSchema()
+	assert.NoError(t, err)
+
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal(schema, &decoded))
+	assert.Equal(t, map[string]interface{}{
+		"$schema":  JSONSchemaDialect,
+		"type":     "object",
+		"required": []interface{}{"email"},
+		"properties": map[string]interface{}{
+			"server": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"port":    map[string]interface{}{"type": "integer", "description": "Port to listen on", "default": float64(8080)},
+					"timeout": map[string]interface{}{"type": "string", "pattern": `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`, "default": "30s"},
+				},
+			},
+			"tags": map[string]interface{}{
+				"type":    "array",
+				"items":   map[string]interface{}{"type": "string"},
+				"default": []interface{}{"a"},
+			},
+			"payment_gateway": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"private_key": map[string]interface{}{"type": "string", "writeOnly": true},
+				},
+			},
+			"email": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"subject"},
+				"properties": map[string]interface{}{
+					"subject": map[string]interface{}{"type": "string", "description": "Subject line", "examples": []interface{}{"Hello"}},
+					"retries": map[string]interface{}{"type": "integer", "minimum": float64(0), "default": float64(0)},
+				},
+			},
+		},
+	}, decoded)
+}
+
+func Test_JSONSchema_ItReturnsErrorIfNoRegisteredValues(t *testing.T) {
+	_, err := New().JSONSchema()
+
+	assert.Equal(t, ErrNoRegisteredValues, err)
+}
@@ -0,0 +1,203 @@
+package configr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// RedactedValue replaces the values of secret keys wherever they would
+// otherwise be output
+const RedactedValue = "******"
+
+// RegisterSecretKey wraps RegisterKey() and marks the key as secret. Secret
+// values (including any values beneath the key, and keys interpolating it)
+// are redacted from explanations, dumps, diffs and error messages.
+func RegisterSecretKey(name, description string, defaultVal interface{}, validators ...Validator) {
+	globalConfigr.RegisterSecretKey(name, description, defaultVal, validators...)
+}
+func (c *Configr) RegisterSecretKey(name, description string, defaultVal interface{}, validators ...Validator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.registerKey(name, description, defaultVal, validators...)
+	c.markSecret(name)
+}
+
+// RequireSecretKey wraps RequireKey() and marks the key as secret, see
+// RegisterSecretKey()
+func RequireSecretKey(name, description string, validators ...Validator) {
+	globalConfigr.RequireSecretKey(name, description, validators...)
+}
+func (c *Configr) RequireSecretKey(name, description string, validators ...Validator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requireKey(name, description, validators...)
+	c.markSecret(name)
+}
+
+// markSecret replaces rather than modifies the set of secret keys as stored
+// trees share it, must be called with c.mu held
+func (c *Configr) markSecret(name string) {
+	if c.isCaseInsensitive {
+		name = strings.ToLower(name)
+	}
+
+	secretKeys := make(map[string]struct{}, len(c.secretKeys)+1)
+	for key := range c.secretKeys {
+		secretKeys[key] = struct{}{}
+	}
+	secretKeys[name] = struct{}{}
+	c.secretKeys = secretKeys
+
+	current := c.load()
+	c.store(current.values, current.parsed)
+}
+
+func (t *tree) isSecret(key string) bool {
+	if _, found := t.interpolatedSecrets[key]; found {
+		return true
+	}
+
+	for secretKey := range t.secretKeys {
+		if key == secretKey || strings.HasPrefix(key, secretKey+t.keyDelimeter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *tree) hasSecretsBeneath(key string) bool {
+	if key == "" {
+		return len(t.secretKeys) > 0 || len(t.interpolatedSecrets) > 0
+	}
+
+	for _, secretKeys := range []map[string]struct{}{t.secretKeys, t.interpolatedSecrets} {
+		for secretKey := range secretKeys {
+			if strings.HasPrefix(secretKey, key+t.keyDelimeter) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (t *tree) normaliseKey(key string) string {
+	if t.isCaseInsensitive {
+		return strings.ToLower(key)
+	}
+
+	return key
+}
+
+func (t *tree) joinKey(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+
+	return parent + t.keyDelimeter + child
+}
+
+// redact returns value with the values of any secret keys (key itself or
+// beneath it) replaced by RedactedValue
+func (t *tree) redact(key string, value interface{}) interface{} {
+	key = t.normaliseKey(key)
+	if t.isSecret(key) {
+		return RedactedValue
+	}
+
+	subMap, ok := value.(map[string]interface{})
+	if !ok || !t.hasSecretsBeneath(key) {
+		return value
+	}
+
+	redacted := make(map[string]interface{}, len(subMap))
+	for subKey, subValue := range subMap {
+		redacted[subKey] = t.redact(t.joinKey(key, subKey), subValue)
+	}
+
+	return redacted
+}
+
+// redactError masks any secret values held in value (the value of key) that
+// appear in err's message
+func (t *tree) redactError(key string, value interface{}, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var secrets []string
+	t.collectSecrets(t.normaliseKey(key), value, false, &secrets)
+	if len(secrets) == 0 {
+		return err
+	}
+
+	// Longest first so a secret containing another is masked whole
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+
+	return redactedError{err: err, secrets: secrets}
+}
+
+func (t *tree) collectSecrets(key string, value interface{}, isSecret bool, secrets *[]string) {
+	isSecret = isSecret || t.isSecret(key)
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for subKey, subValue := range v {
+			t.collectSecrets(t.joinKey(key, subKey), subValue, isSecret, secrets)
+		}
+	case []interface{}:
+		if isSecret {
+			*secrets = append(*secrets, fmt.Sprint(v))
+		}
+		for _, element := range v {
+			t.collectSecrets(key, element, isSecret, secrets)
+		}
+	default:
+		if !isSecret {
+			return
+		}
+		if s, err := cast.ToStringE(v); err == nil && s != "" {
+			*secrets = append(*secrets, s)
+		}
+		if s := fmt.Sprint(v); s != "" {
+			*secrets = append(*secrets, s)
+		}
+	}
+}
+
+// redactedError masks secrets in the message of the error it wraps. Errors
+// beneath it are only ever unwrapped masked as well, so errors.Is still
+// matches sentinel errors but errors.As can't reach the original errors.
+type redactedError struct {
+	err     error
+	secrets []string
+}
+
+func (e redactedError) Error() string {
+	message := e.err.Error()
+	for _, secret := range e.secrets {
+		message = strings.Replace(message, secret, RedactedValue, -1)
+	}
+
+	return message
+}
+
+func (e redactedError) Unwrap() error {
+	wrapped := errors.Unwrap(e.err)
+	if wrapped == nil {
+		return nil
+	}
+
+	return redactedError{err: wrapped, secrets: e.secrets}
+}
+
+func (e redactedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
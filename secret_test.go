@@ -0,0 +1,176 @@
+package configr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errBadKey = errors.New("bad key")
+
+func Test_RegisterSecretKey_ItRedactsValidationErrors(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"payment_gateway": map[string]interface{}{"private_key": "hunter2"},
+	}})
+	config.RegisterSecretKey("payment_gateway.private_key", "", nil, func(value interface{}) error {
+		return fmt.Errorf("%w: '%v' is too short", errBadKey, value)
+	})
+
+	err := config.Parse()
+
+	assert.EqualError(t, err.(ParseErrors)[0], "Validation error on key 'payment_gateway.private_key' from source 's1': bad key: '******' is too short")
+	assert.NotContains(t, err.Error(), "hunter2")
+	assert.True(t, errors.Is(err, errBadKey))
+}
+
+func Test_RegisterSecretKey_ItRedactsErrorsWrappedByValidationErrors(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"token": "hunter2"}})
+	config.RegisterSecretKey("token", "", nil, func(value interface{}) error {
+		return fmt.Errorf("invalid token: %w", fmt.Errorf("%w %v", errBadKey, value))
+	})
+
+	err := config.Parse()
+
+	var validationErr ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	for wrapped := validationErr.Err; wrapped != nil; wrapped = errors.Unwrap(wrapped) {
+		assert.NotContains(t, wrapped.Error(), "hunter2")
+	}
+	assert.Equal(t, "bad key ******", errors.Unwrap(validationErr.Err).Error())
+	assert.True(t, errors.Is(err, errBadKey))
+}
+
+func Test_RegisterSecretKey_ItRedactsConversionErrors(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"token": "hunter2"}})
+	config.RegisterSecretKey("token", "", nil)
+	assert.NoError(t, config.Parse())
+
+	_, err := config.Int("token")
+
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "hunter2")
+	assert.Contains(t, err.Error(), RedactedValue)
+
+	var destination struct {
+		Token int `configr:"token"`
+	}
+	err = config.Unmarshal(&destination)
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "hunter2")
+}
+
+func Test_RegisterSecretKey_ItRedactsExplanations(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"payment_gateway": map[string]interface{}{"private_key": "hunter2", "public_key": "abc"},
+	}})
+	config.AddSource(namedSource{name: "s2", values: map[string]interface{}{
+		"payment_gateway": map[string]interface{}{"private_key": "hunter1"},
+	}})
+	config.RegisterSecretKey("payment_gateway.private_key", "", nil)
+	assert.NoError(t, config.Parse())
+
+	explanation, err := config.Explain("payment_gateway.private_key")
+	assert.NoError(t, err)
+	assert.Equal(t, RedactedValue, explanation.Value)
+	assert.Equal(t, RedactedValue, explanation.Origin.Value)
+	assert.Equal(t, RedactedValue, explanation.Overridden[0].Value)
+	assert.NotContains(t, explanation.String(), "hunter")
+
+	explanation, err = config.Explain("payment_gateway")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"private_key": RedactedValue, "public_key": "abc"}, explanation.Value)
+
+	value, _ := config.String("payment_gateway.private_key")
+	assert.Equal(t, "hunter2", value)
+}
+
+func Test_RegisterSecretKey_ItRedactsKeysInterpolatedFromSecrets(t *testing.T) {
+	config := New()
+	config.SetInterpolation(true)
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"db":  map[string]interface{}{"password": "hunter2", "url": "postgres://u:${db.password}@h"},
+		"dsn": "${db.url}",
+	}})
+	config.RegisterSecretKey("db.password", "", nil)
+	config.RegisterKey("db.url", "", nil)
+	config.RegisterKey("dsn", "", nil)
+	assert.NoError(t, config.Parse())
+
+	for _, key := range []string{"db.url", "dsn"} {
+		explanation, explainErr := config.Explain(key)
+		assert.NoError(t, explainErr)
+		assert.Equal(t, RedactedValue, explanation.Value, key)
+	}
+	explanation, _ := config.Explain("db")
+	assert.NotContains(t, explanation.String(), "hunter2")
+	dumped, dumpErr := config.Dump(EncoderAdapter(func(v interface{}) ([]byte, error) {
+		return []byte(fmt.Sprint(v)), nil
+	}), DumpOptions{})
+	assert.NoError(t, dumpErr)
+	assert.NotContains(t, string(dumped), "hunter2")
+	assert.NotContains(t, Diff(New(), config).String(), "hunter2")
+
+	value, _ := config.String("db.url")
+	assert.Equal(t, "postgres://u:hunter2@h", value)
+}
+
+func Test_RegisterSecretKey_ItRedactsErrorsOfKeysInterpolatedFromSecrets(t *testing.T) {
+	config := New()
+	config.SetInterpolation(true)
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"password": "hunter2",
+		"dsn":      "postgres://u:${password}@h",
+	}})
+	config.RegisterSecretKey("password", "", nil)
+	config.RegisterKey("dsn", "", nil, func(value interface{}) error {
+		return fmt.Errorf("unreachable %v", value)
+	})
+
+	err := config.Parse()
+
+	assert.EqualError(t, err.(ParseErrors)[0], "Validation error on key 'dsn' from source 's1': unreachable ******")
+}
+
+func Test_RegisterSecretKey_ItLeavesSecretDefaultsOutOfBlanks(t *testing.T) {
+	config := New()
+	g := &MockGenerator{}
+	config.RegisterSecretKey("db.password", "Database password", "hunter2")
+	config.RegisterKey("db.user", "Database user", "admin")
+
+	g.On("Marshal", map[string]interface{}{
+		"db": map[string]interface{}{
+			"password": "*** Database password ***",
+			"user":     "admin",
+		},
+	}).Return([]byte{}, nil)
+
+	_, err := config.GenerateBlank(g)
+
+	assert.NoError(t, err)
+	g.AssertExpectations(t)
+}
+
+func Test_RegisterFromStruct_ItMarksSecretFields(t *testing.T) {
+	config := New()
+	err := config.RegisterFromStruct(&struct {
+		Gateway struct {
+			PrivateKey string `configr:"private_key,required,secret"`
+		} `configr:"gateway"`
+		Database struct {
+			Password string `configr:"password"`
+		} `configr:"database,secret"`
+	}{})
+	assert.NoError(t, err)
+
+	tree := config.load()
+	assert.True(t, tree.isSecret("gateway.private_key"))
+	assert.True(t, tree.isSecret("database.password"))
+	assert.False(t, tree.isSecret("gateway"))
+	assert.Contains(t, config.requiredKeys, "gateway.private_key")
+}
@@ -0,0 +1,244 @@
+package configr
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// Snapshot is an immutable, point in time view of the values held by a
+// Configr. It exposes the same read methods as Configr and is unaffected by
+// any later calls to Parse() or reloads, so a request handler can capture one
+// and read consistently throughout.
+//
+// Snapshots are cheap to create and comparable, two snapshots are == when
+// they were taken from the same set of values. Equal() compares the values
+// themselves.
+type Snapshot struct {
+	tree *tree
+}
+
+// GetSnapshot returns a Snapshot of the current values
+func GetSnapshot() Snapshot {
+	return globalConfigr.Snapshot()
+}
+func (c *Configr) Snapshot() Snapshot {
+	return Snapshot{c.load()}
+}
+
+// Equal reports whether both snapshots hold the same values
+func (s Snapshot) Equal(other Snapshot) bool {
+	if s == other {
+		return true
+	}
+	if s.tree == nil || other.tree == nil {
+		return false
+	}
+
+	return s.tree.parsed == other.tree.parsed && reflect.DeepEqual(s.tree.values, other.tree.values)
+}
+
+// Parsed reports whether the snapshot was taken after a successful Parse()
+func (s Snapshot) Parsed() bool {
+	return s.tree != nil && s.tree.parsed
+}
+
+// Get follows the same rules as Configr.Get(), maps and slices are copies so
+// changing them doesn't change the snapshot
+func (s Snapshot) Get(key string) (interface{}, error) {
+	value, err := s.tree.get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return copyValue(value), nil
+}
+
+// String wraps Get() and will attempt to cast the resulting value to a string
+// or error
+func (s Snapshot) String(key string) (string, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return "", err
+	}
+	converted, err := cast.ToStringE(val)
+	return converted, newConversionError(key, "string", s.tree.redactError(key, val, err))
+}
+
+// Bool wraps Get() and will attempt to cast the resulting value to a bool
+// or error
+func (s Snapshot) Bool(key string) (bool, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return false, err
+	}
+	converted, err := cast.ToBoolE(val)
+	return converted, newConversionError(key, "bool", s.tree.redactError(key, val, err))
+}
+
+// Int wraps Get() and will attempt to cast the resulting value to a int
+// or error
+func (s Snapshot) Int(key string) (int, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	converted, err := cast.ToIntE(val)
+	return converted, newConversionError(key, "int", s.tree.redactError(key, val, err))
+}
+
+// Float64 wraps Get() and will attempt to cast the resulting value to a
+// float64 or error
+func (s Snapshot) Float64(key string) (float64, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	converted, err := cast.ToFloat64E(val)
+	return converted, newConversionError(key, "float64", s.tree.redactError(key, val, err))
+}
+
+// Int64 wraps Get() and will attempt to cast the resulting value to an int64
+// or error
+func (s Snapshot) Int64(key string) (int64, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	converted, err := cast.ToInt64E(val)
+	return converted, newConversionError(key, "int64", s.tree.redactError(key, val, err))
+}
+
+// Uint wraps Get() and will attempt to cast the resulting value to a uint
+// or error
+func (s Snapshot) Uint(key string) (uint, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	converted, err := cast.ToUintE(val)
+	return converted, newConversionError(key, "uint", s.tree.redactError(key, val, err))
+}
+
+// Uint64 wraps Get() and will attempt to cast the resulting value to a uint64
+// or error
+func (s Snapshot) Uint64(key string) (uint64, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	converted, err := cast.ToUint64E(val)
+	return converted, newConversionError(key, "uint64", s.tree.redactError(key, val, err))
+}
+
+// Duration wraps Get() and will attempt to cast the resulting value to a
+// time.Duration or error, strings are parsed as durations e.g. "30s"
+func (s Snapshot) Duration(key string) (time.Duration, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	converted, err := cast.ToDurationE(val)
+	return converted, newConversionError(key, "time.Duration", s.tree.redactError(key, val, err))
+}
+
+// Time wraps Get() and will attempt to cast the resulting value to a time.Time
+// or error, strings are parsed using common formats including RFC3339
+func (s Snapshot) Time(key string) (time.Time, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	converted, err := cast.ToTimeE(val)
+	return converted, newConversionError(key, "time.Time", s.tree.redactError(key, val, err))
+}
+
+// StringSlice wraps Get() and will attempt to cast the resulting value to a
+// []string or error, strings are split on commas e.g. "a, b"
+func (s Snapshot) StringSlice(key string) ([]string, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := toStringSlice(val)
+	return converted, newConversionError(key, "[]string", s.tree.redactError(key, val, err))
+}
+
+// IntSlice wraps Get() and will attempt to cast the resulting value to a
+// []int or error, strings are split on commas e.g. "1, 2"
+func (s Snapshot) IntSlice(key string) ([]int, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := toIntSlice(val)
+	return converted, newConversionError(key, "[]int", s.tree.redactError(key, val, err))
+}
+
+// StringMapString wraps Get() and will attempt to cast the resulting value to
+// a map[string]string or error, strings are parsed as a JSON object or comma
+// separated pairs e.g. "a=1, b=2"
+func (s Snapshot) StringMapString(key string) (map[string]string, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := toStringMapString(val)
+	return converted, newConversionError(key, "map[string]string", s.tree.redactError(key, val, err))
+}
+
+// Unmarshal follows the same rules as Configr.Unmarshal()
+func (s Snapshot) Unmarshal(destination interface{}) error {
+	return s.UnmarshalKey("", destination)
+}
+
+// UnmarshalKey follows the same rules as Configr.UnmarshalKey()
+func (s Snapshot) UnmarshalKey(key string, destination interface{}) error {
+	return s.tree.unmarshalKey(key, destination)
+}
+
+func copyMap(source map[string]interface{}) map[string]interface{} {
+	target := make(map[string]interface{}, len(source))
+	for key, value := range source {
+		target[key] = copyValue(value)
+	}
+
+	return target
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyMap(v)
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i := range v {
+			s[i] = copyValue(v[i])
+		}
+		return s
+	}
+
+	// Maps and slices of specific types (e.g. a []string declared for a key)
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Slice:
+		if reflected.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(reflected.Type(), reflected.Len(), reflected.Len())
+		reflect.Copy(copied, reflected)
+		return copied.Interface()
+	case reflect.Map:
+		if reflected.IsNil() {
+			return value
+		}
+		copied := reflect.MakeMapWithSize(reflected.Type(), reflected.Len())
+		for iter := reflected.MapRange(); iter.Next(); {
+			copied.SetMapIndex(iter.Key(), iter.Value())
+		}
+		return copied.Interface()
+	}
+
+	return value
+}
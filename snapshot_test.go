@@ -0,0 +1,130 @@
+package configr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func countingSource() Source {
+	calls := 0
+	return SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		calls++
+		return map[string]interface{}{
+			"t1": calls,
+			"t2": map[string]interface{}{
+				"t21": "2.5",
+				"t22": "true",
+			},
+		}, nil
+	})
+}
+
+func Test_Snapshot_ItIsUnaffectedByLaterParses(t *testing.T) {
+	config := New()
+	config.AddSource(countingSource())
+	assert.NoError(t, config.Parse())
+
+	snapshot := config.Snapshot()
+	assert.NoError(t, config.Reload())
+
+	before, err := snapshot.Int("t1")
+	assert.NoError(t, err)
+	after, err := config.Int("t1")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, before)
+	assert.Equal(t, 2, after)
+}
+
+func Test_Snapshot_ItIsUnaffectedByChangesToReturnedValues(t *testing.T) {
+	config := New()
+	config.AddSource(countingSource())
+	config.RegisterKey("tags", "", []string{"a", "b"})
+	assert.NoError(t, config.Parse())
+
+	snapshot := config.Snapshot()
+	subTree, err := config.Get("t2")
+	assert.NoError(t, err)
+	subTree.(map[string]interface{})["t21"] = 99
+	tags, err := config.StringSlice("tags")
+	assert.NoError(t, err)
+	tags[0] = "z"
+
+	value, _ := snapshot.Get("t2")
+	assert.Equal(t, map[string]interface{}{"t21": "2.5", "t22": "true"}, value)
+	tags, _ = snapshot.StringSlice("tags")
+	assert.Equal(t, []string{"a", "b"}, tags)
+}
+
+func Test_Snapshot_ItIsUnaffectedByChangesToUnmarshalledValues(t *testing.T) {
+	config := New()
+	config.AddSource(countingSource())
+	assert.NoError(t, config.Parse())
+
+	snapshot := config.Snapshot()
+	all := map[string]interface{}{}
+	assert.NoError(t, config.Unmarshal(&all))
+	all["t2"].(map[string]interface{})["t21"] = 99
+	var subTree interface{}
+	assert.NoError(t, config.UnmarshalKey("t2", &subTree))
+	subTree.(map[string]interface{})["t22"] = 99
+
+	value, _ := snapshot.Get("t2")
+	assert.Equal(t, map[string]interface{}{"t21": "2.5", "t22": "true"}, value)
+}
+
+func Test_Snapshot_ItExposesTheSameReadMethodsAsConfigr(t *testing.T) {
+	config := New()
+	config.AddSource(countingSource())
+	assert.NoError(t, config.Parse())
+	snapshot := config.Snapshot()
+
+	t1, err := snapshot.String("t1")
+	assert.NoError(t, err)
+	t2t21, err := snapshot.Float64("t2.t21")
+	assert.NoError(t, err)
+	t2t22, err := snapshot.Bool("t2.t22")
+	assert.NoError(t, err)
+	t2 := struct {
+		T21 float64
+		T22 bool
+	}{}
+	assert.NoError(t, snapshot.UnmarshalKey("t2", &t2))
+
+	assert.Equal(t, "1", t1)
+	assert.Equal(t, 2.5, t2t21)
+	assert.True(t, t2t22)
+	assert.Equal(t, 2.5, t2.T21)
+	assert.True(t, t2.T22)
+}
+
+func Test_Snapshot_ItErrorsIfTakenBeforeParsing(t *testing.T) {
+	config := New()
+	snapshot := config.Snapshot()
+
+	_, err := snapshot.Get("t1")
+
+	assert.False(t, snapshot.Parsed())
+	assert.Equal(t, ErrParseHasntBeenCalled, err)
+	assert.Equal(t, ErrParseHasntBeenCalled, Snapshot{}.Unmarshal(&struct{}{}))
+}
+
+func Test_Snapshot_ItIsComparable(t *testing.T) {
+	config := New()
+	config.AddSource(SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		return map[string]interface{}{"t1": 1}, nil
+	}))
+	assert.NoError(t, config.Parse())
+
+	s1, s2 := config.Snapshot(), config.Snapshot()
+	assert.True(t, s1 == s2)
+
+	assert.NoError(t, config.Reload())
+	s3 := config.Snapshot()
+	assert.False(t, s1 == s3)
+	assert.True(t, s1.Equal(s3))
+
+	config.RegisterKey("t2", "", 2)
+	assert.False(t, s1.Equal(config.Snapshot()))
+}
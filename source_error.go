@@ -0,0 +1,109 @@
+package configr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SourceError wraps an error returned by a source with the source's name (see
+// Named) and, for file decoders that report where decoding failed, the line
+// and column (counted from 1) in the file. Line and Column are 0 when unknown.
+type SourceError struct {
+	Source string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e SourceError) Error() string {
+	switch {
+	case e.Line > 0 && e.Column > 0:
+		return fmt.Sprintf("configr: Error in source '%s' at line %d, column %d: %v", e.Source, e.Line, e.Column, e.Err)
+	case e.Line > 0:
+		return fmt.Sprintf("configr: Error in source '%s' at line %d: %v", e.Source, e.Line, e.Err)
+	}
+
+	return fmt.Sprintf("configr: Error in source '%s': %v", e.Source, e.Err)
+}
+
+func (e SourceError) Unwrap() error {
+	return e.Err
+}
+
+// PositionedError is implemented by FileDecoder errors which know the line
+// and column (0 if unknown) in the input they occurred at. JSON syntax and
+// type errors are positioned from their byte offsets without it.
+type PositionedError interface {
+	error
+	Position() (line, column int)
+}
+
+// newSourceError wraps err with the name of the source it came from, errors
+// already attributed to a source (e.g. decode errors from a File) are left as
+// they are.
+func newSourceError(name string, err error) error {
+	var sourceErr SourceError
+	if errors.As(err, &sourceErr) {
+		return err
+	}
+
+	return SourceError{Source: name, Err: err}
+}
+
+// newDecodeError wraps an error from decoding input with the source's name
+// and, where the decoder reports it, the position it failed at
+func newDecodeError(name string, input []byte, err error) error {
+	sourceErr := SourceError{Source: name, Err: err}
+
+	var (
+		syntaxErr     *json.SyntaxError
+		typeErr       *json.UnmarshalTypeError
+		positionedErr PositionedError
+	)
+	switch {
+	case errors.As(err, &syntaxErr):
+		sourceErr.Line, sourceErr.Column = offsetToPosition(input, syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		sourceErr.Line, sourceErr.Column = offsetToPosition(input, typeErr.Offset)
+	case errors.As(err, &positionedErr):
+		sourceErr.Line, sourceErr.Column = positionedErr.Position()
+	}
+
+	return sourceErr
+}
+
+// offsetToPosition converts a byte offset into input to a line and column,
+// the offset is of the byte after the one decoding failed on
+func offsetToPosition(input []byte, offset int64) (int, int) {
+	if offset > int64(len(input)) {
+		offset = int64(len(input))
+	}
+	if offset > 0 {
+		offset--
+	}
+
+	before := input[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+
+	return line, column
+}
+
+// attributeErrors records the source that supplied the values errs were
+// found in on any validation or conversion errors
+func attributeErrors(name string, errs []error) []error {
+	for i, err := range errs {
+		switch err := err.(type) {
+		case ValidationError:
+			err.Source = name
+			errs[i] = err
+		case ConversionError:
+			err.Source = name
+			errs[i] = err
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,101 @@
+package configr
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTempFile(t *testing.T, pattern, content string) string {
+	file, err := ioutil.TempFile("", pattern)
+	assert.NoError(t, err)
+	file.WriteString(content)
+	file.Close()
+
+	return file.Name()
+}
+
+func Test_Parse_ItReportsTheFileAndPositionOfDecodeErrors(t *testing.T) {
+	defer resetGlobals()()
+	RegisterFileDecoder("json", FileDecoderAdapter(json.Unmarshal), "json")
+
+	path := writeTempFile(t, "configr*.json", "{\n\t\"t1\": 1,\n\t}\n")
+	defer os.Remove(path)
+
+	config := New()
+	config.AddSource(NewFile(path))
+
+	err := config.Parse()
+
+	var sourceErr SourceError
+	assert.True(t, errors.As(err, &sourceErr))
+	assert.Equal(t, path, sourceErr.Source)
+	assert.Equal(t, 3, sourceErr.Line)
+	assert.Equal(t, 2, sourceErr.Column)
+	assert.EqualError(t, sourceErr, "configr: Error in source '"+path+"' at line 3, column 2: invalid character '}' looking for beginning of object key string")
+}
+
+func Test_Parse_ItReportsPositionedDecoderErrors(t *testing.T) {
+	defer resetGlobals()()
+	RegisterFileDecoder("test", FileDecoderAdapter(func([]byte, interface{}) error {
+		return positionedError{line: 4}
+	}), "test")
+
+	path := writeTempFile(t, "configr*.test", "")
+	defer os.Remove(path)
+
+	file := NewFile(path)
+	file.SetName("app config")
+	config := New()
+	config.AddSource(file)
+
+	err := config.Parse()
+
+	assert.Equal(t, ParseErrors{SourceError{Source: "app config", Line: 4, Err: positionedError{line: 4}}}, err)
+	assert.EqualError(t, err.(ParseErrors)[0], "configr: Error in source 'app config' at line 4: bad value")
+}
+
+type positionedError struct {
+	line int
+}
+
+func (e positionedError) Error() string {
+	return "bad value"
+}
+
+func (e positionedError) Position() (int, int) {
+	return e.line, 0
+}
+
+func Test_Parse_ItReportsTheSourceOfValidationErrors(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"t1": 1}})
+	config.AddSource(namedSource{name: "s2", values: map[string]interface{}{"t1": 2, "t2": "abc"}})
+	config.RegisterKey("t1", "", nil, func(value interface{}) error {
+		return errors.New("invalid")
+	})
+	config.RegisterKey("t2", "", 0)
+
+	err := config.Parse()
+
+	assert.Equal(t, ParseErrors{
+		ValidationError{Key: "t1", Source: "s2", Err: errors.New("invalid")},
+		ConversionError{Key: "t2", Type: "int", Source: "s2", Err: errors.New(`unable to cast "abc" of type string to int64`)},
+		ValidationError{Key: "t1", Source: "s1", Err: errors.New("invalid")},
+	}, err)
+	assert.EqualError(t, err.(ParseErrors)[0], "Validation error on key 't1' from source 's2': invalid")
+	assert.EqualError(t, err.(ParseErrors)[1], `configr: Unable to convert key 't2' from source 's2' to int: unable to cast "abc" of type string to int64`)
+}
+
+func Test_offsetToPosition_ItCountsLinesAndColumnsFromOne(t *testing.T) {
+	input := []byte("ab\ncd\n")
+
+	for offset, expected := range map[int64][2]int{0: {1, 1}, 1: {1, 1}, 2: {1, 2}, 4: {2, 1}, 5: {2, 2}, 100: {2, 3}} {
+		line, column := offsetToPosition(input, offset)
+		assert.Equal(t, expected, [2]int{line, column}, "offset %d", offset)
+	}
+}
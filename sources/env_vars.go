@@ -23,6 +23,21 @@ func NewEnvVars(prefix string) *EnvVars {
 	}
 }
 
+// Name satisfies the configr.Named interface
+func (e *EnvVars) Name() string {
+	if e.prefix == "" {
+		return "env"
+	}
+
+	return "env:" + strings.ToUpper(e.prefix)
+}
+
+// Locate satisfies the configr.KeyLocator interface, returning the name of
+// the environment variable a key is read from
+func (e *EnvVars) Locate(key string, keySplitter configr.KeySplitter) string {
+	return toEnvVarKey(e.prefix, key, keySplitter)
+}
+
 func (e *EnvVars) Unmarshal(keys []string, keySplitter configr.KeySplitter) (map[string]interface{}, error) {
 	returnMap := map[string]interface{}{}
 
@@ -73,3 +73,11 @@ func Test_ItUnmarhsalsKeysFromEnvironmentalVariablesWithPrefix(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expectedKeyValues, actual)
 }
+
+func Test_ItLocatesTheEnvironmentalVariableForAKey(t *testing.T) {
+	envVars := NewEnvVars("configr")
+
+	assert.Equal(t, "env:CONFIGR", envVars.Name())
+	assert.Equal(t, "CONFIGR_T1_T11", envVars.Locate("t1.t11", configr.NewKeySplitter(".")))
+	assert.Equal(t, "env", NewEnvVars("").Name())
+}
@@ -0,0 +1,171 @@
+package toml
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/adrianduke/configr"
+)
+
+var bareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Encoder encodes TOML and satisfies configr.AnnotatedEncoder, writing key
+// annotations as comments above each key
+type Encoder struct{}
+
+func (Encoder) Marshal(v interface{}) ([]byte, error) {
+	return marshal(v)
+}
+
+// MarshalAnnotated writes keys in the order given, though TOML requires a
+// table's keys to be written before its sub tables
+func (Encoder) MarshalAnnotated(annotations []configr.KeyAnnotation) ([]byte, error) {
+	root := newTable()
+	for i := range annotations {
+		root.add(annotations[i].Path, &annotations[i])
+	}
+
+	var buf bytes.Buffer
+	if err := root.write(&buf, nil); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimLeft(buf.Bytes(), "\n"), nil
+}
+
+type entry struct {
+	name       string
+	annotation *configr.KeyAnnotation
+	value      interface{}
+}
+
+type table struct {
+	annotation *configr.KeyAnnotation
+	entries    []entry
+	tableNames []string
+	tables     map[string]*table
+}
+
+func newTable() *table {
+	return &table{tables: make(map[string]*table)}
+}
+
+func (t *table) add(path []string, annotation *configr.KeyAnnotation) {
+	if len(path) > 1 {
+		t.subTable(path[0]).add(path[1:], annotation)
+		return
+	}
+
+	if values, ok := annotation.Value.(map[string]interface{}); ok {
+		sub := t.subTable(path[0])
+		sub.annotation = annotation
+		sub.addValues(values)
+		return
+	}
+
+	t.entries = append(t.entries, entry{name: path[0], annotation: annotation, value: annotation.Value})
+}
+
+// addValues adds the plain values of a map default
+func (t *table) addValues(values map[string]interface{}) {
+	for _, name := range sortedNames(values) {
+		if subValues, ok := values[name].(map[string]interface{}); ok {
+			t.subTable(name).addValues(subValues)
+			continue
+		}
+		t.entries = append(t.entries, entry{name: name, value: values[name]})
+	}
+}
+
+func (t *table) subTable(name string) *table {
+	if sub, found := t.tables[name]; found {
+		return sub
+	}
+
+	sub := newTable()
+	t.tables[name] = sub
+	t.tableNames = append(t.tableNames, name)
+
+	return sub
+}
+
+func (t *table) write(buf *bytes.Buffer, path []string) error {
+	if len(path) > 0 {
+		buf.WriteString("\n")
+		writeComments(buf, t.annotation)
+		quoted := make([]string, len(path))
+		for i, name := range path {
+			quoted[i] = quoteKey(name)
+		}
+		fmt.Fprintf(buf, "[%s]\n", strings.Join(quoted, "."))
+	}
+
+	for i, e := range t.entries {
+		value, err := encodeValue(e.value)
+		if err != nil {
+			return err
+		}
+		if i > 0 && e.annotation != nil && len(e.annotation.Comments()) > 0 {
+			buf.WriteString("\n")
+		}
+		writeComments(buf, e.annotation)
+		fmt.Fprintf(buf, "%s = %s\n", quoteKey(e.name), value)
+	}
+
+	for _, name := range t.tableNames {
+		if err := t.tables[name].write(buf, append(path, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeComments(buf *bytes.Buffer, annotation *configr.KeyAnnotation) {
+	if annotation == nil {
+		return
+	}
+
+	for _, line := range annotation.Comments() {
+		fmt.Fprintf(buf, "# %s\n", line)
+	}
+}
+
+// encodeValue encodes a single value by encoding it under a placeholder key
+// and stripping the key back off
+func encodeValue(value interface{}) (string, error) {
+	if duration, ok := value.(time.Duration); ok {
+		value = duration.String()
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]interface{}{"v": value}); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(buf.String(), "v = ")), nil
+}
+
+func quoteKey(name string) string {
+	if bareKey.MatchString(name) {
+		return name
+	}
+
+	return strconv.Quote(name)
+}
+
+func sortedNames(values map[string]interface{}) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
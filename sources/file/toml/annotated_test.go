@@ -0,0 +1,41 @@
+package toml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/adrianduke/configr"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MarshalAnnotated_ItWritesAnnotationsAsComments(t *testing.T) {
+	annotations := []configr.KeyAnnotation{
+		{Path: []string{"server", "port"}, Description: "Port to listen on", Type: "int", Default: 8080, Value: 8080},
+		{Path: []string{"name"}, Required: true, Value: ""},
+		{Path: []string{"server", "timeout"}, Value: 30 * time.Second},
+		{Path: []string{"labels"}, Value: map[string]interface{}{"team key": "ops"}},
+		{Path: []string{"debug"}, Value: false},
+	}
+
+	encoded, err := Encoder{}.MarshalAnnotated(annotations)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `# required
+name = ""
+debug = false
+
+[server]
+# Port to listen on
+# type: int, default: 8080
+port = 8080
+timeout = "30s"
+
+[labels]
+"team key" = "ops"
+`, string(encoded))
+
+	decoded := map[string]interface{}{}
+	assert.NoError(t, toml.Unmarshal(encoded, &decoded))
+	assert.Equal(t, "ops", decoded["labels"].(map[string]interface{})["team key"])
+}
@@ -3,6 +3,8 @@ package toml
 import (
 	"bufio"
 	"bytes"
+	"regexp"
+	"strconv"
 
 	"github.com/BurntSushi/toml"
 	"github.com/adrianduke/configr"
@@ -15,17 +17,54 @@ func init() {
 }
 
 func Register() {
-	configr.RegisterFileDecoder(Name, configr.FileDecoderAdapter(toml.Unmarshal), "toml", "TOML")
-
-	tomlEncoder := func(v interface{}) ([]byte, error) {
-		var tomlBytes bytes.Buffer
-		tomlEncoder := toml.NewEncoder(bufio.NewWriter(&tomlBytes))
-		err := tomlEncoder.Encode(v)
-		if err != nil {
-			return tomlBytes.Bytes(), err
-		}
+	configr.RegisterFileDecoder(Name, configr.FileDecoderAdapter(unmarshal), "toml", "TOML")
+
+	configr.RegisterFileEncoder(Name, Encoder{}, "toml", "TOML")
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	var tomlBytes bytes.Buffer
+	tomlEncoder := toml.NewEncoder(bufio.NewWriter(&tomlBytes))
+	err := tomlEncoder.Encode(v)
+	if err != nil {
+		return tomlBytes.Bytes(), err
+	}
+
+	return tomlBytes.Bytes(), nil
+}
+
+var nearLine = regexp.MustCompile(`^Near line (\d+) `)
+
+// decodeError satisfies configr.PositionedError for parse errors, which only
+// report the line they occurred near in their message
+type decodeError struct {
+	line int
+	err  error
+}
 
-		return tomlBytes.Bytes(), nil
+func (e decodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e decodeError) Unwrap() error {
+	return e.err
+}
+
+func (e decodeError) Position() (int, int) {
+	return e.line, 0
+}
+
+func unmarshal(data []byte, v interface{}) error {
+	err := toml.Unmarshal(data, v)
+	if err == nil {
+		return nil
 	}
-	configr.RegisterFileEncoder(Name, configr.EncoderAdapter(tomlEncoder), "toml", "TOML")
+
+	if match := nearLine.FindStringSubmatch(err.Error()); match != nil {
+		if line, convErr := strconv.Atoi(match[1]); convErr == nil {
+			return decodeError{line, err}
+		}
+	}
+
+	return err
 }
@@ -0,0 +1,19 @@
+package toml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_unmarshal_ItReportsTheLineOfParseErrors(t *testing.T) {
+	values := map[string]interface{}{}
+
+	err := unmarshal([]byte("a = 1\nb = \n"), &values)
+
+	positioned, ok := err.(interface{ Position() (int, int) })
+	assert.True(t, ok)
+	line, column := positioned.Position()
+	assert.Equal(t, 2, line)
+	assert.Equal(t, 0, column)
+}
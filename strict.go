@@ -0,0 +1,136 @@
+package configr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// UnknownKeyError is returned in strict mode when a source provides a key
+// that hasn't been registered. Suggestion is the closest registered key, if
+// one is close enough to be a likely typo.
+type UnknownKeyError struct {
+	Source     string
+	Key        string
+	Suggestion string
+}
+
+func (e UnknownKeyError) Error() string {
+	return fmt.Sprintf("configr: Unknown key '%s' from %s%s", e.Key, e.Source, e.hint())
+}
+
+func (e UnknownKeyError) errorKey() string {
+	return e.Key
+}
+
+func (e UnknownKeyError) keyMessage() string {
+	return "unknown key from " + e.Source + e.hint()
+}
+
+func (e UnknownKeyError) hint() string {
+	if e.Suggestion == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(", did you mean '%s'?", e.Suggestion)
+}
+
+// SetStrict enables strict mode, in which Parse() fails when a source provides
+// keys that haven't been registered. Values beneath a registered key are
+// allowed, e.g. "email.subject" when "email" is registered.
+func SetStrict(strict bool) {
+	globalConfigr.SetStrict(strict)
+}
+func (c *Configr) SetStrict(strict bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.isStrict = strict
+}
+
+func (c *Configr) checkUnknownKeys(name string, sourceValues map[string]interface{}) []error {
+	var errs []error
+
+	flattened := flattenMap(sourceValues, c.keyDelimeter)
+	for _, key := range sortedKeys(flattened) {
+		if c.isCaseInsensitive {
+			key = strings.ToLower(key)
+		}
+
+		if !c.isKnownKey(key) {
+			errs = append(errs, UnknownKeyError{
+				Source:     name,
+				Key:        key,
+				Suggestion: c.suggestKey(key),
+			})
+		}
+	}
+
+	return errs
+}
+
+func (c *Configr) isKnownKey(key string) bool {
+	for registeredKey := range c.registeredKeys {
+		if key == registeredKey || strings.HasPrefix(key, registeredKey+c.keyDelimeter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// suggestKey returns the registered key with the smallest edit distance to
+// key, as long as it's within a third of the key's length
+func (c *Configr) suggestKey(key string) string {
+	registeredKeys := make([]string, 0, len(c.registeredKeys))
+	for registeredKey := range c.registeredKeys {
+		registeredKeys = append(registeredKeys, registeredKey)
+	}
+	sort.Strings(registeredKeys)
+
+	suggestion, bestDistance := "", utf8.RuneCountInString(key)/3+1
+	for _, registeredKey := range registeredKeys {
+		if distance := editDistance(key, registeredKey); distance < bestDistance {
+			suggestion, bestDistance = registeredKey, distance
+		}
+	}
+
+	return suggestion
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+
+	return a
+}
@@ -0,0 +1,56 @@
+package configr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse_ItIgnoresUnknownKeysByDefault(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"emial.subject": "Hi"}})
+	config.RegisterKey("email.subject", "", "")
+
+	assert.NoError(t, config.Parse())
+}
+
+func Test_Parse_ItRejectsUnknownKeysInStrictMode(t *testing.T) {
+	config := New()
+	config.SetStrict(true)
+	config.AddSource(namedSource{name: "/tmp/config.json", values: map[string]interface{}{
+		"emial": map[string]interface{}{
+			"subject": "Hi",
+		},
+		"server": map[string]interface{}{
+			"tls": map[string]interface{}{"cert": "/tmp/cert"},
+		},
+		"completelyUnrelated": true,
+	}})
+	config.RegisterKey("email.subject", "", "")
+	config.RegisterKey("server", "", nil)
+
+	err := config.Parse()
+
+	assert.Equal(t, ParseErrors{
+		UnknownKeyError{Source: "/tmp/config.json", Key: "completelyUnrelated"},
+		UnknownKeyError{Source: "/tmp/config.json", Key: "emial.subject", Suggestion: "email.subject"},
+	}, err)
+	assert.EqualError(t, err.(ParseErrors)[1], "configr: Unknown key 'emial.subject' from /tmp/config.json, did you mean 'email.subject'?")
+}
+
+func Test_Parse_ItMatchesUnknownKeysCaseInsensitively(t *testing.T) {
+	config := New()
+	config.SetStrict(true)
+	config.SetIsCaseSensitive(false)
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"Email.Subject": "Hi"}})
+	config.RegisterKey("email.subject", "", "")
+
+	assert.NoError(t, config.Parse())
+}
+
+func Test_editDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("email", "email"))
+	assert.Equal(t, 2, editDistance("emial", "email"))
+	assert.Equal(t, 1, editDistance("emails", "email"))
+	assert.Equal(t, 5, editDistance("", "email"))
+}
@@ -0,0 +1,75 @@
+package configr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TagRule builds a Validator from a `configr` struct tag option, e.g. the
+// option "min=1" on an int field calls the "min" TagRule with "1" and the
+// field's type. Options without a value pass an empty string.
+type TagRule func(string, reflect.Type) (Validator, error)
+
+var (
+	RegisteredTagRules = make(map[string]TagRule)
+
+	ErrUnknownTagRule = errors.New("configr: Unknown tag rule")
+)
+
+// RegisterTagRule makes a rule available to `configr` struct tags under name,
+// the validators package registers the built in rules (min, max, oneof,
+// regex, nonempty...) when imported.
+func RegisterTagRule(name string, rule TagRule) {
+	RegisteredTagRules[name] = rule
+}
+
+type TagRuleError struct {
+	Field  string
+	Option string
+	Err    error
+}
+
+func (e TagRuleError) Error() string {
+	return fmt.Sprintf("configr: Invalid tag option '%s' on field %s: %v", e.Option, e.Field, e.Err)
+}
+
+func (e TagRuleError) Unwrap() error {
+	return e.Err
+}
+
+// tagValidators builds a Validator for every rule in a `configr` tag's
+// options. Options without a value that aren't rules (e.g. "squash") are left
+// for mapstructure, options with a value must name a registered rule.
+func tagValidators(field reflect.StructField, options []string) ([]Validator, error) {
+	validators := []Validator{}
+
+	for _, option := range options {
+		if option == "" || option == tagRequired || option == tagSecret {
+			continue
+		}
+
+		parts := strings.SplitN(option, "=", 2)
+		rule, found := RegisteredTagRules[parts[0]]
+		if !found {
+			if len(parts) == 1 {
+				continue
+			}
+			return nil, TagRuleError{field.Name, option, ErrUnknownTagRule}
+		}
+
+		arg := ""
+		if len(parts) == 2 {
+			arg = parts[1]
+		}
+
+		validator, err := rule(arg, field.Type)
+		if err != nil {
+			return nil, TagRuleError{field.Name, option, err}
+		}
+		validators = append(validators, validator)
+	}
+
+	return validators, nil
+}
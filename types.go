@@ -0,0 +1,73 @@
+package configr
+
+import (
+	"reflect"
+	"strings"
+)
+
+// SetKeyType declares the type of a key's values, Parse() converts values
+// from sources to it (e.g. an env var's "30s" to a time.Duration) and reports
+// any that can't be converted as ConversionErrors. Keys registered from
+// struct fields take the field's type and keys with defaults the default's
+// type, SetKeyType overrides both.
+func SetKeyType(name string, keyType reflect.Type) {
+	globalConfigr.SetKeyType(name, keyType)
+}
+func (c *Configr) SetKeyType(name string, keyType reflect.Type) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.isCaseInsensitive {
+		name = strings.ToLower(name)
+	}
+	c.keyTypes[name] = keyType
+}
+
+// coerceAndValidate converts value to the declared types of key and any keys
+// beneath it, validating the result when every conversion succeeds
+func (c *Configr) coerceAndValidate(key string, value interface{}) (interface{}, []error) {
+	value, errs := c.coerceValue(key, value)
+	if len(errs) > 0 {
+		return value, errs
+	}
+
+	return value, c.runValidators(key, value)
+}
+
+func (c *Configr) coerceValue(key string, value interface{}) (interface{}, []error) {
+	keyType := c.keyType(key)
+
+	if subMap, ok := value.(map[string]interface{}); ok && (keyType == nil || isSubtreeType(keyType)) {
+		var errs []error
+		coerced := make(map[string]interface{}, len(subMap))
+		for _, subKey := range sortedKeys(subMap) {
+			fullKey := key + c.keyDelimeter + subKey
+			if c.isCaseInsensitive {
+				fullKey = strings.ToLower(fullKey)
+			}
+
+			var subErrs []error
+			coerced[subKey], subErrs = c.coerceValue(fullKey, subMap[subKey])
+			errs = append(errs, subErrs...)
+		}
+		return coerced, errs
+	}
+
+	if keyType == nil || (c.isInterpolating && hasReferences(value)) {
+		return value, nil
+	}
+
+	coerced, err := coerce(value, keyType)
+	if err != nil {
+		err = c.load().redactError(key, value, err)
+		return value, []error{newConversionError(key, keyType.String(), err)}
+	}
+
+	return coerced, nil
+}
+
+// isSubtreeType reports whether a key of type t holds keys which may have
+// their own types, e.g. a key defaulting to a map[string]interface{}
+func isSubtreeType(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Elem().Kind() == reflect.Interface
+}
@@ -0,0 +1,153 @@
+package configr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse_ItCoercesValuesToTheirDeclaredTypes(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "env", values: map[string]interface{}{
+		"server.port":    "8080",
+		"server.timeout": "30s",
+		"debug":          "true",
+		"tags":           "a, b",
+		"ids":            "1,2",
+		"labels":         "team=ops",
+		"ratio":          "0.5",
+		"explicit":       "5",
+		"untyped":        "5",
+	}})
+	config.RegisterKey("server.port", "", 80)
+	config.RegisterKey("server.timeout", "", time.Second)
+	config.RegisterKey("debug", "", false)
+	config.RegisterKey("tags", "", []string{})
+	config.RegisterKey("ids", "", []int{})
+	config.RegisterKey("labels", "", map[string]string{})
+	config.RegisterKey("explicit", "", nil)
+	config.RegisterKey("untyped", "", nil)
+	config.SetKeyType("explicit", reflect.TypeOf(uint8(0)))
+	assert.NoError(t, config.RegisterFromStruct(&struct {
+		Ratio float32 `configr:"ratio,required"`
+	}{}))
+
+	assert.NoError(t, config.Parse())
+
+	for key, expected := range map[string]interface{}{
+		"server.port":    8080,
+		"server.timeout": 30 * time.Second,
+		"debug":          true,
+		"tags":           []string{"a", "b"},
+		"ids":            []int{1, 2},
+		"labels":         map[string]string{"team": "ops"},
+		"ratio":          float32(0.5),
+		"explicit":       uint8(5),
+		"untyped":        "5",
+	} {
+		value, err := config.Get(key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, key)
+	}
+}
+
+func Test_Parse_ItCoercesNestedValuesBeforeValidating(t *testing.T) {
+	var validated interface{}
+
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"server": map[string]interface{}{"port": float64(8080)},
+	}})
+	config.RegisterKey("server.port", "", 80, func(v interface{}) error {
+		validated = v
+		return nil
+	})
+
+	assert.NoError(t, config.Parse())
+	assert.Equal(t, 8080, validated)
+}
+
+func Test_Parse_ItReportsValuesThatCantBeConverted(t *testing.T) {
+	validated := false
+
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"server": map[string]interface{}{"port": "abc", "retries": 1.5, "small": 300},
+	}})
+	config.RegisterKey("server.port", "", 80, func(interface{}) error {
+		validated = true
+		return nil
+	})
+	config.RegisterKey("server.retries", "", 3)
+	config.RegisterKey("server.small", "", int8(1))
+
+	err := config.Parse()
+
+	assert.Len(t, err, 3)
+	var conversionErr ConversionError
+	assert.True(t, errors.As(err, &conversionErr))
+	assert.Equal(t, "server.port", conversionErr.Key)
+	assert.Equal(t, `configr: 3 errors parsing configuration:
+  server.port:
+    - unable to convert to int: unable to cast "abc" of type string to int64 (from s1)
+  server.retries:
+    - unable to convert to int: 1.5 is not a whole number (from s1)
+  server.small:
+    - unable to convert to int8: 300 overflows int8 (from s1)`, err.Error())
+	assert.False(t, validated)
+}
+
+func Test_Parse_ItRedactsSecretsFromConversionErrors(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{"pin": "hunter2"}})
+	config.RegisterSecretKey("pin", "", 1234)
+
+	err := config.Parse()
+
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "hunter2")
+}
+
+type testItem struct {
+	Name string
+	Size int
+}
+
+func Test_Parse_ItLeavesSlicesOfStructsForUnmarshal(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"Items": []interface{}{map[string]interface{}{"name": "a", "size": 1}},
+	}})
+	assert.NoError(t, config.RegisterFromStruct(&struct {
+		Items []testItem
+	}{}))
+
+	assert.NoError(t, config.Parse())
+
+	destination := struct {
+		Items []testItem
+	}{}
+	assert.NoError(t, config.Unmarshal(&destination))
+	assert.Equal(t, []testItem{{Name: "a", Size: 1}}, destination.Items)
+}
+
+func Test_Parse_ItLeavesMapsOfStructsForUnmarshal(t *testing.T) {
+	config := New()
+	config.AddSource(namedSource{name: "s1", values: map[string]interface{}{
+		"Items": map[string]interface{}{"first": map[string]interface{}{"name": "a", "size": 1}},
+	}})
+	assert.NoError(t, config.RegisterFromStruct(&struct {
+		Items map[string]testItem
+	}{}))
+
+	assert.NoError(t, config.Parse())
+
+	destination := struct {
+		Items map[string]testItem
+	}{}
+	assert.NoError(t, config.Unmarshal(&destination))
+	assert.Equal(t, map[string]testItem{"first": {Name: "a", Size: 1}}, destination.Items)
+}
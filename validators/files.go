@@ -0,0 +1,54 @@
+package validators
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/adrianduke/configr"
+)
+
+// ExistingFile passes paths to an existing file which isn't a directory
+func ExistingFile() configr.Validator {
+	return func(v interface{}) error {
+		info, err := stat(v)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return fmt.Errorf("must be a file, %s is a directory", info.Name())
+		}
+
+		return nil
+	}
+}
+
+// ExistingDir passes paths to an existing directory
+func ExistingDir() configr.Validator {
+	return func(v interface{}) error {
+		info, err := stat(v)
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return fmt.Errorf("must be a directory, %s is a file", info.Name())
+		}
+
+		return nil
+	}
+}
+
+func stat(v interface{}) (os.FileInfo, error) {
+	path, err := toString(v)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("must exist: %v", err)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,84 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/adrianduke/configr"
+)
+
+// URL passes absolute URLs, with both a scheme and host
+func URL() configr.Validator {
+	return func(v interface{}) error {
+		s, err := toString(v)
+		if err != nil {
+			return err
+		}
+
+		u, err := url.Parse(s)
+		if err != nil {
+			return fmt.Errorf("must be a valid URL: %v", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return errors.New("must be an absolute URL with a scheme and host")
+		}
+
+		return nil
+	}
+}
+
+// HostPort passes "host:port" addresses with a numeric port, the host may be
+// empty e.g. ":8080"
+func HostPort() configr.Validator {
+	return func(v interface{}) error {
+		s, err := toString(v)
+		if err != nil {
+			return err
+		}
+
+		_, port, err := net.SplitHostPort(s)
+		if err != nil {
+			return fmt.Errorf("must be a valid host:port: %v", err)
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return fmt.Errorf("must have a numeric port between 0 and 65535, got %q", port)
+		}
+
+		return nil
+	}
+}
+
+// IP passes IPv4 and IPv6 addresses
+func IP() configr.Validator {
+	return func(v interface{}) error {
+		s, err := toString(v)
+		if err != nil {
+			return err
+		}
+
+		if net.ParseIP(s) == nil {
+			return fmt.Errorf("must be a valid IP address, got %q", s)
+		}
+
+		return nil
+	}
+}
+
+// CIDR passes IP networks in CIDR notation e.g. "10.0.0.0/8"
+func CIDR() configr.Validator {
+	return func(v interface{}) error {
+		s, err := toString(v)
+		if err != nil {
+			return err
+		}
+
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			return fmt.Errorf("must be a valid CIDR: %v", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+package validators
+
+import (
+	"fmt"
+
+	"github.com/adrianduke/configr"
+	"github.com/spf13/cast"
+)
+
+// Min passes numbers greater than or equal to min
+func Min(min float64) configr.Validator {
+	return func(v interface{}) error {
+		n, err := toNumber(v)
+		if err != nil {
+			return err
+		}
+
+		if n < min {
+			return fmt.Errorf("must be at least %v, got %v", min, n)
+		}
+
+		return nil
+	}
+}
+
+// Max passes numbers less than or equal to max
+func Max(max float64) configr.Validator {
+	return func(v interface{}) error {
+		n, err := toNumber(v)
+		if err != nil {
+			return err
+		}
+
+		if n > max {
+			return fmt.Errorf("must be at most %v, got %v", max, n)
+		}
+
+		return nil
+	}
+}
+
+// Range passes numbers between min and max inclusive
+func Range(min, max float64) configr.Validator {
+	return All(Min(min), Max(max))
+}
+
+func toNumber(v interface{}) (float64, error) {
+	n, err := cast.ToFloat64E(v)
+	if err != nil {
+		return 0, fmt.Errorf("must be a number: %v", err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,110 @@
+package validators
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/adrianduke/configr"
+	"github.com/spf13/cast"
+)
+
+// MinLength passes strings of at least min characters
+func MinLength(min int) configr.Validator {
+	return func(v interface{}) error {
+		s, err := toString(v)
+		if err != nil {
+			return err
+		}
+
+		if length := utf8.RuneCountInString(s); length < min {
+			return fmt.Errorf("must be at least %d characters long, got %d", min, length)
+		}
+
+		return nil
+	}
+}
+
+// MaxLength passes strings of at most max characters
+func MaxLength(max int) configr.Validator {
+	return func(v interface{}) error {
+		s, err := toString(v)
+		if err != nil {
+			return err
+		}
+
+		if length := utf8.RuneCountInString(s); length > max {
+			return fmt.Errorf("must be at most %d characters long, got %d", max, length)
+		}
+
+		return nil
+	}
+}
+
+// Length passes strings between min and max characters inclusive
+func Length(min, max int) configr.Validator {
+	return All(MinLength(min), MaxLength(max))
+}
+
+// Regex passes strings matching pattern, it panics if pattern doesn't compile
+func Regex(pattern string) configr.Validator {
+	re := regexp.MustCompile(pattern)
+
+	return func(v interface{}) error {
+		s, err := toString(v)
+		if err != nil {
+			return err
+		}
+
+		if !re.MatchString(s) {
+			return fmt.Errorf("must match pattern %s", pattern)
+		}
+
+		return nil
+	}
+}
+
+// OneOf passes values equal to one of the allowed strings
+func OneOf(allowed ...string) configr.Validator {
+	return func(v interface{}) error {
+		s, err := toString(v)
+		if err != nil {
+			return err
+		}
+
+		for _, a := range allowed {
+			if s == a {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("must be one of [%s], got %q", strings.Join(allowed, ", "), s)
+	}
+}
+
+// Email passes strings which are a valid email address (RFC 5322)
+func Email() configr.Validator {
+	return func(v interface{}) error {
+		s, err := toString(v)
+		if err != nil {
+			return err
+		}
+
+		if _, err := mail.ParseAddress(s); err != nil {
+			return fmt.Errorf("must be a valid email address: %v", err)
+		}
+
+		return nil
+	}
+}
+
+func toString(v interface{}) (string, error) {
+	s, err := cast.ToStringE(v)
+	if err != nil {
+		return "", fmt.Errorf("must be a string: %v", err)
+	}
+
+	return s, nil
+}
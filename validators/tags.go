@@ -0,0 +1,83 @@
+package validators
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/adrianduke/configr"
+)
+
+var ErrMissingTagArgument = errors.New("rule requires a value e.g. min=1")
+
+func init() {
+	RegisterTagRules()
+}
+
+// RegisterTagRules makes the validators available to `configr` struct tags
+// used with RegisterFromStruct:
+//    min=N, max=N     Min/Max, or MinLength/MaxLength on string fields
+//    oneof=a|b|c      OneOf
+//    regex=pattern    Regex, the pattern can't contain commas
+//    nonempty, url, hostport, ip, cidr, file, dir, duration, email
+func RegisterTagRules() {
+	configr.RegisterTagRule("min", boundRule(Min, MinLength))
+	configr.RegisterTagRule("max", boundRule(Max, MaxLength))
+	configr.RegisterTagRule("oneof", func(arg string, _ reflect.Type) (configr.Validator, error) {
+		if arg == "" {
+			return nil, ErrMissingTagArgument
+		}
+		return OneOf(strings.Split(arg, "|")...), nil
+	})
+	configr.RegisterTagRule("regex", func(arg string, _ reflect.Type) (configr.Validator, error) {
+		if _, err := regexp.Compile(arg); err != nil {
+			return nil, err
+		}
+		return Regex(arg), nil
+	})
+
+	flags := map[string]func() configr.Validator{
+		"nonempty": NonEmpty,
+		"url":      URL,
+		"hostport": HostPort,
+		"ip":       IP,
+		"cidr":     CIDR,
+		"file":     ExistingFile,
+		"dir":      ExistingDir,
+		"duration": Duration,
+		"email":    Email,
+	}
+	for name, constructor := range flags {
+		configr.RegisterTagRule(name, flagRule(constructor))
+	}
+}
+
+func boundRule(numeric func(float64) configr.Validator, length func(int) configr.Validator) configr.TagRule {
+	return func(arg string, fieldType reflect.Type) (configr.Validator, error) {
+		if arg == "" {
+			return nil, ErrMissingTagArgument
+		}
+
+		if fieldType.Kind() == reflect.String {
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				return nil, err
+			}
+			return length(n), nil
+		}
+
+		n, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, err
+		}
+		return numeric(n), nil
+	}
+}
+
+func flagRule(constructor func() configr.Validator) configr.TagRule {
+	return func(_ string, _ reflect.Type) (configr.Validator, error) {
+		return constructor(), nil
+	}
+}
@@ -0,0 +1,81 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/adrianduke/configr"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TagRules_ItValidatesKeysRegisteredFromStructs(t *testing.T) {
+	config := configr.New()
+	testStruct := struct {
+		Port  int    `configr:"port,required,min=1,max=65535"`
+		Level string `configr:"level,oneof=debug|info|warn"`
+		Name  string `configr:"name,nonempty,max=3"`
+		Host  string `configr:"host,regex=^[a-z]+$"`
+	}{
+		Level: "info",
+		Name:  "app",
+		Host:  "localhost",
+	}
+	assert.NoError(t, config.RegisterFromStruct(&testStruct))
+
+	config.AddSource(configr.SourceAdapter(func(_ []string, _ configr.KeySplitter) (map[string]interface{}, error) {
+		return map[string]interface{}{
+			"port":  "0",
+			"level": "trace",
+			"name":  "application",
+			"host":  "LOCALHOST",
+		}, nil
+	}))
+	err := config.Parse()
+
+	assert.EqualError(t, err, `configr: 4 errors parsing configuration:
+  host:
+    - must match pattern ^[a-z]+$ (from source #1 (configr.SourceAdapter))
+  level:
+    - must be one of [debug, info, warn], got "trace" (from source #1 (configr.SourceAdapter))
+  name:
+    - must be at most 3 characters long, got 11 (from source #1 (configr.SourceAdapter))
+  port:
+    - must be at least 1, got 0 (from source #1 (configr.SourceAdapter))`)
+}
+
+func Test_TagRules_ItErrorsOnInvalidRules(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value interface{}
+		err   string
+	}{
+		{
+			name: "unknown rule",
+			value: &struct {
+				T1 int `configr:"t1,between=1|2"`
+			}{},
+			err: "configr: Invalid tag option 'between=1|2' on field T1: configr: Unknown tag rule",
+		},
+		{
+			name: "invalid argument",
+			value: &struct {
+				T1 int `configr:"t1,min=one"`
+			}{},
+			err: `configr: Invalid tag option 'min=one' on field T1: strconv.ParseFloat: parsing "one": invalid syntax`,
+		},
+		{
+			name: "missing argument",
+			value: &struct {
+				T1 string `configr:"t1,oneof"`
+			}{},
+			err: "configr: Invalid tag option 'oneof' on field T1: rule requires a value e.g. min=1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := configr.New().RegisterFromStruct(testCase.value)
+
+			assert.EqualError(t, err, testCase.err)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package validators provides composable configr.Validator constructors for
+// common configuration constraints. Values are coerced using the same cast
+// rules as configr's typed getters, so a string "5" from an environmental
+// variable validates the same as an int 5.
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/adrianduke/configr"
+	"github.com/spf13/cast"
+)
+
+var ErrEmpty = errors.New("must not be empty")
+
+// All passes only when every validator passes, returning the first error
+func All(validators ...configr.Validator) configr.Validator {
+	return func(v interface{}) error {
+		for _, validate := range validators {
+			if err := validate(v); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Any passes when at least one validator passes, otherwise every error is
+// returned in a single error
+func Any(validators ...configr.Validator) configr.Validator {
+	return func(v interface{}) error {
+		messages := make([]string, 0, len(validators))
+		for _, validate := range validators {
+			err := validate(v)
+			if err == nil {
+				return nil
+			}
+			messages = append(messages, err.Error())
+		}
+
+		return errors.New("must satisfy one of: " + strings.Join(messages, "; "))
+	}
+}
+
+// Not passes when validator fails, reason describes what the value must not
+// be and is used as the error e.g. Not(OneOf("root"), "must not be root")
+func Not(validator configr.Validator, reason string) configr.Validator {
+	return func(v interface{}) error {
+		if err := validator(v); err != nil {
+			return nil
+		}
+
+		return errors.New(reason)
+	}
+}
+
+// Optional skips validator when the value is nil or an empty string
+func Optional(validator configr.Validator) configr.Validator {
+	return func(v interface{}) error {
+		if v == nil || v == "" {
+			return nil
+		}
+
+		return validator(v)
+	}
+}
+
+// NonEmpty fails on nil, empty strings (after trimming whitespace) and empty
+// slices or maps
+func NonEmpty() configr.Validator {
+	return func(v interface{}) error {
+		if v == nil {
+			return ErrEmpty
+		}
+
+		if s, ok := v.(string); ok {
+			if strings.TrimSpace(s) == "" {
+				return ErrEmpty
+			}
+			return nil
+		}
+
+		switch reflect.ValueOf(v).Kind() {
+		case reflect.Slice, reflect.Map, reflect.Array:
+			if reflect.ValueOf(v).Len() == 0 {
+				return ErrEmpty
+			}
+		}
+
+		return nil
+	}
+}
+
+// Duration passes values that can be converted to a time.Duration e.g. "30s"
+func Duration() configr.Validator {
+	return func(v interface{}) error {
+		if _, err := cast.ToDurationE(v); err != nil {
+			return fmt.Errorf("must be a valid duration: %v", err)
+		}
+
+		return nil
+	}
+}
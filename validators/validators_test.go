@@ -0,0 +1,132 @@
+package validators
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/adrianduke/configr"
+	"github.com/stretchr/testify/assert"
+)
+
+type validatorCase struct {
+	name      string
+	validator configr.Validator
+	value     interface{}
+	err       string
+}
+
+func runValidatorCases(t *testing.T, testCases []validatorCase) {
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.validator(testCase.value)
+			if testCase.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.err)
+			}
+		})
+	}
+}
+
+func failing(message string) configr.Validator {
+	return func(interface{}) error {
+		return errors.New(message)
+	}
+}
+
+func passing() configr.Validator {
+	return func(interface{}) error {
+		return nil
+	}
+}
+
+func Test_Combinators(t *testing.T) {
+	runValidatorCases(t, []validatorCase{
+		{name: "All passes", validator: All(passing(), passing()), value: 1},
+		{name: "All returns first error", validator: All(passing(), failing("a"), failing("b")), value: 1, err: "a"},
+		{name: "Any passes", validator: Any(failing("a"), passing()), value: 1},
+		{name: "Any returns all errors", validator: Any(failing("a"), failing("b")), value: 1, err: "must satisfy one of: a; b"},
+		{name: "Not passes", validator: Not(failing("a"), "must not pass"), value: 1},
+		{name: "Not fails", validator: Not(passing(), "must not pass"), value: 1, err: "must not pass"},
+		{name: "Optional skips nil", validator: Optional(failing("a")), value: nil},
+		{name: "Optional skips empty string", validator: Optional(failing("a")), value: ""},
+		{name: "Optional validates", validator: Optional(failing("a")), value: "b", err: "a"},
+	})
+}
+
+func Test_NonEmptyAndDuration(t *testing.T) {
+	runValidatorCases(t, []validatorCase{
+		{name: "NonEmpty string", validator: NonEmpty(), value: "a"},
+		{name: "NonEmpty number", validator: NonEmpty(), value: 0},
+		{name: "NonEmpty nil", validator: NonEmpty(), value: nil, err: "must not be empty"},
+		{name: "NonEmpty blank string", validator: NonEmpty(), value: "  ", err: "must not be empty"},
+		{name: "NonEmpty empty slice", validator: NonEmpty(), value: []string{}, err: "must not be empty"},
+		{name: "NonEmpty empty map", validator: NonEmpty(), value: map[string]interface{}{}, err: "must not be empty"},
+		{name: "Duration string", validator: Duration(), value: "30s"},
+		{name: "Duration int", validator: Duration(), value: 30},
+		{name: "Duration invalid", validator: Duration(), value: "thirty", err: `must be a valid duration: time: invalid duration "thirty"`},
+	})
+}
+
+func Test_Numbers(t *testing.T) {
+	runValidatorCases(t, []validatorCase{
+		{name: "Min int", validator: Min(1), value: 1},
+		{name: "Min string", validator: Min(1), value: "5"},
+		{name: "Min too small", validator: Min(1), value: 0, err: "must be at least 1, got 0"},
+		{name: "Min not a number", validator: Min(1), value: "a", err: `must be a number: unable to cast "a" of type string to float64`},
+		{name: "Max float", validator: Max(1.5), value: 1.5},
+		{name: "Max too large", validator: Max(1.5), value: "2", err: "must be at most 1.5, got 2"},
+		{name: "Range within", validator: Range(1, 65535), value: "8080"},
+		{name: "Range outside", validator: Range(1, 65535), value: 65536, err: "must be at most 65535, got 65536"},
+	})
+}
+
+func Test_Strings(t *testing.T) {
+	runValidatorCases(t, []validatorCase{
+		{name: "MinLength", validator: MinLength(2), value: "ab"},
+		{name: "MinLength too short", validator: MinLength(2), value: "a", err: "must be at least 2 characters long, got 1"},
+		{name: "MaxLength counts runes", validator: MaxLength(2), value: "éé"},
+		{name: "MaxLength too long", validator: MaxLength(2), value: 123, err: "must be at most 2 characters long, got 3"},
+		{name: "Length", validator: Length(1, 3), value: "ab"},
+		{name: "Regex", validator: Regex(`^[a-z]+$`), value: "abc"},
+		{name: "Regex no match", validator: Regex(`^[a-z]+$`), value: "ABC", err: "must match pattern ^[a-z]+$"},
+		{name: "OneOf", validator: OneOf("debug", "info"), value: "info"},
+		{name: "OneOf coerces", validator: OneOf("1", "2"), value: 2},
+		{name: "OneOf not allowed", validator: OneOf("debug", "info"), value: "warn", err: `must be one of [debug, info], got "warn"`},
+		{name: "Email", validator: Email(), value: "my@email.com"},
+		{name: "Email invalid", validator: Email(), value: "my.email.com", err: "must be a valid email address: mail: missing '@' or angle-addr"},
+	})
+}
+
+func Test_Network(t *testing.T) {
+	runValidatorCases(t, []validatorCase{
+		{name: "URL", validator: URL(), value: "https://github.com/adrianduke/configr"},
+		{name: "URL relative", validator: URL(), value: "/adrianduke/configr", err: "must be an absolute URL with a scheme and host"},
+		{name: "HostPort", validator: HostPort(), value: "localhost:8080"},
+		{name: "HostPort empty host", validator: HostPort(), value: ":8080"},
+		{name: "HostPort missing port", validator: HostPort(), value: "localhost", err: "must be a valid host:port: address localhost: missing port in address"},
+		{name: "HostPort invalid port", validator: HostPort(), value: "localhost:http", err: `must have a numeric port between 0 and 65535, got "http"`},
+		{name: "IP v4", validator: IP(), value: "127.0.0.1"},
+		{name: "IP v6", validator: IP(), value: "::1"},
+		{name: "IP invalid", validator: IP(), value: "localhost", err: `must be a valid IP address, got "localhost"`},
+		{name: "CIDR", validator: CIDR(), value: "10.0.0.0/8"},
+		{name: "CIDR invalid", validator: CIDR(), value: "10.0.0.0", err: "must be a valid CIDR: invalid CIDR address: 10.0.0.0"},
+	})
+}
+
+func Test_Files(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configr")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file, err := ioutil.TempFile(dir, "file")
+	assert.NoError(t, err)
+	file.Close()
+
+	assert.NoError(t, ExistingFile()(file.Name()))
+	assert.Error(t, ExistingFile()(dir))
+	assert.Error(t, ExistingFile()(dir+"/missing"))
+	assert.NoError(t, ExistingDir()(dir))
+	assert.Error(t, ExistingDir()(file.Name()))
+}
@@ -0,0 +1,166 @@
+package configr
+
+import (
+	"context"
+	"time"
+)
+
+const DefaultWatchInterval = 2 * time.Second
+
+// Watchable is implemented by Sources which can report whether their
+// underlying data has changed since they were last unmarshalled. Watch()
+// polls Changed() to decide when a reload is needed.
+type Watchable interface {
+	Changed() (bool, error)
+}
+
+// ChangeHandler is called once a reload has swapped in new values, old and new
+// are snapshots of the values before and after the reload.
+type ChangeHandler func(old, new Snapshot)
+
+// OnChange registers a handler to be called whenever Watch() or Reload()
+// reloads the configuration and the resulting values differ from the previous
+// ones.
+func OnChange(handler ChangeHandler) {
+	globalConfigr.OnChange(handler)
+}
+func (c *Configr) OnChange(handler ChangeHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.changeHandlers = append(c.changeHandlers, handler)
+}
+
+// OnReloadError registers a handler to be called whenever a reload triggered
+// by Watch() fails, the previous values are kept in place.
+func OnReloadError(handler func(error)) {
+	globalConfigr.OnReloadError(handler)
+}
+func (c *Configr) OnReloadError(handler func(error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reloadErrorHandlers = append(c.reloadErrorHandlers, handler)
+}
+
+func (c *Configr) SetWatchInterval(interval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.watchInterval = interval
+}
+
+// Watch polls all Watchable sources every watch interval, when any of them
+// report a change every source is unmarshalled again into a fresh set of
+// values. Validators and required keys are checked against the new values and
+// only if they pass are they swapped in, after which OnChange handlers are
+// notified. ctx is passed to sources while reloading (see ParseContext()),
+// Watch blocks until ctx is done.
+func Watch(ctx context.Context) error {
+	return globalConfigr.Watch(ctx)
+}
+func (c *Configr) Watch(ctx context.Context) error {
+	c.mu.Lock()
+	interval := c.watchInterval
+	c.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			c.poll(ctx)
+		}
+	}
+}
+
+func (c *Configr) poll(ctx context.Context) {
+	c.mu.Lock()
+	changed, err := c.sourcesChanged()
+	handlers := c.reloadErrorHandlers
+	c.mu.Unlock()
+
+	if err == nil && changed {
+		err = c.reload(ctx)
+	}
+
+	if err != nil {
+		for _, handler := range handlers {
+			handler(err)
+		}
+	}
+}
+
+func (c *Configr) sourcesChanged() (bool, error) {
+	for i, source := range c.sources {
+		if c.sourceCalls[i].busy() {
+			// Still being unmarshalled in the background
+			continue
+		}
+		if watchable, ok := source.(Watchable); ok {
+			changed, err := watchable.Changed()
+			if err != nil {
+				return false, err
+			}
+			if changed {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// Reload rebuilds the values from defaults and every source into a fresh
+// tree, unlike Parse() nothing from the current values is carried over so a
+// key removed from a source disappears. Validators and required keys are
+// checked against the new values and only if they pass are they swapped in,
+// otherwise the current values are kept and the errors returned. OnChange
+// handlers are called once the new values have been stored.
+func Reload() error {
+	return globalConfigr.Reload()
+}
+func (c *Configr) Reload() error {
+	return c.reload(context.Background())
+}
+
+func (c *Configr) reload(ctx context.Context) error {
+	old, new, err := c.rebuild(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	handlers := c.changeHandlers
+	c.mu.Unlock()
+
+	if !old.Equal(new) {
+		for _, handler := range handlers {
+			handler(old, new)
+		}
+	}
+
+	return nil
+}
+
+func (c *Configr) rebuild(ctx context.Context) (Snapshot, Snapshot, error) {
+	c.mu.Lock()
+	state := newParseState(make(map[string]interface{}))
+	defer func() { c.unlockAndWarn(state.warnings) }()
+
+	for _, key := range sortedKeys(c.defaultValues) {
+		c.mergeMap(key, c.defaultValues[key], state.values)
+	}
+
+	if err := c.parseInto(ctx, state); err != nil {
+		return Snapshot{}, Snapshot{}, err
+	}
+
+	old := c.Snapshot()
+	c.storeTree(state.tree())
+
+	return old, c.Snapshot(), nil
+}
@@ -0,0 +1,143 @@
+package configr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWatchableSource struct {
+	MockSource
+	changed bool
+}
+
+func (m *MockWatchableSource) Changed() (bool, error) {
+	return m.changed, nil
+}
+
+func Test_Reload_ItRebuildsValuesFromScratch(t *testing.T) {
+	config := New()
+	s1 := &MockSource{}
+	s1.On("Unmarshal", mock.AnythingOfType("[]string"), mock.AnythingOfType("KeySplitter")).Return(map[string]interface{}{"t1": 1}, nil)
+
+	config.RegisterKey("t2", "", 2)
+	config.AddSource(s1)
+	config.store(map[string]interface{}{"stale": true}, false)
+
+	assert.NoError(t, config.Reload())
+	assert.Equal(t, map[string]interface{}{"t1": 1, "t2": 2}, config.load().values)
+	assert.True(t, config.Parsed())
+}
+
+func Test_Reload_ItKeepsPreviousValuesIfValidationFails(t *testing.T) {
+	config := New()
+	s1 := &MockSource{}
+	s1.On("Unmarshal", mock.AnythingOfType("[]string"), mock.AnythingOfType("KeySplitter")).Return(map[string]interface{}{"t1": 1}, nil)
+
+	config.RegisterKey("t1", "", 0, func(v interface{}) error {
+		return errors.New("!")
+	})
+	config.AddSource(s1)
+
+	assert.Error(t, config.Reload())
+	assert.Equal(t, map[string]interface{}{"t1": 0}, config.load().values)
+}
+
+func Test_Reload_ItNotifiesChangeHandlersWithOldAndNewSnapshots(t *testing.T) {
+	config := New()
+	calls := 0
+	s1 := SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		calls++
+		return map[string]interface{}{"t1": calls}, nil
+	})
+
+	var oldValue, newValue interface{}
+	config.AddSource(s1)
+	config.OnChange(func(old, new Snapshot) {
+		oldValue, _ = old.Get("t1")
+		newValue, _ = new.Get("t1")
+	})
+
+	assert.NoError(t, config.Parse())
+	assert.NoError(t, config.Reload())
+	assert.Equal(t, 1, oldValue)
+	assert.Equal(t, 2, newValue)
+}
+
+func Test_Reload_ItDoesntNotifyChangeHandlersIfNothingChanged(t *testing.T) {
+	config := New()
+	s1 := &MockSource{}
+	s1.On("Unmarshal", mock.AnythingOfType("[]string"), mock.AnythingOfType("KeySplitter")).Return(map[string]interface{}{"t1": 1}, nil)
+
+	notified := 0
+	config.AddSource(s1)
+	config.OnChange(func(old, new Snapshot) {
+		notified++
+	})
+
+	assert.NoError(t, config.Reload())
+	assert.NoError(t, config.Reload())
+	assert.Equal(t, 1, notified)
+}
+
+func Test_poll_ItOnlyReloadsWhenAWatchableSourceHasChanged(t *testing.T) {
+	config := New()
+	s1 := &MockWatchableSource{}
+	s1.On("Unmarshal", mock.AnythingOfType("[]string"), mock.AnythingOfType("KeySplitter")).Return(map[string]interface{}{"t1": 1}, nil)
+	config.AddSource(s1)
+
+	config.poll(context.Background())
+	s1.AssertNotCalled(t, "Unmarshal", mock.Anything, mock.Anything)
+
+	s1.changed = true
+	config.poll(context.Background())
+	s1.AssertNumberOfCalls(t, "Unmarshal", 1)
+}
+
+func Test_poll_ItPassesReloadErrorsToHandlers(t *testing.T) {
+	config := New()
+	s1 := &MockWatchableSource{changed: true}
+	s1.On("Unmarshal", mock.AnythingOfType("[]string"), mock.AnythingOfType("KeySplitter")).Return(map[string]interface{}{}, errors.New("!"))
+
+	var reloadErr error
+	config.AddSource(s1)
+	config.OnReloadError(func(err error) {
+		reloadErr = err
+	})
+	config.poll(context.Background())
+
+	assert.Equal(t, ParseErrors{SourceError{Source: "source #1 (*configr.MockWatchableSource)", Err: errors.New("!")}}, reloadErr)
+}
+
+func Test_Watch_ItReturnsWhenContextIsDone(t *testing.T) {
+	config := New()
+	config.SetWatchInterval(time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, config.Watch(ctx))
+}
+
+func Test_Reload_ItDropsKeysRemovedFromSources(t *testing.T) {
+	values := map[string]interface{}{"t1": 1, "t2": map[string]interface{}{"t21": 2}}
+	config := New()
+	config.AddSource(SourceAdapter(func(_ []string, _ KeySplitter) (map[string]interface{}, error) {
+		return copyMap(values), nil
+	}))
+	assert.NoError(t, config.Parse())
+
+	delete(values, "t2")
+	assert.NoError(t, config.Parse())
+	_, err := config.Get("t2.t21")
+	assert.NoError(t, err, "Parse() merges on top of the current values")
+
+	assert.NoError(t, config.Reload())
+	_, err = config.Get("t2.t21")
+	assert.Equal(t, ErrKeyNotFound, err)
+	value, _ := config.Int("t1")
+	assert.Equal(t, 1, value)
+}